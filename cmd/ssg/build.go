@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/jeroendee/ssg/internal/builder"
 	"github.com/jeroendee/ssg/internal/config"
@@ -53,7 +54,15 @@ func runBuild(configPath, outputDir, contentDir, assetsDir string) error {
 	if err := b.Build(); err != nil {
 		return fmt.Errorf("building site: %w", err)
 	}
+	printWarnings(b.Warnings())
 
 	fmt.Println("Site built successfully!")
 	return nil
 }
+
+// printWarnings writes the warnings of a build to stderr.
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+}
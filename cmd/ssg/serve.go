@@ -73,6 +73,7 @@ func runServeWithContext(ctx context.Context, configPath string, port int, dir s
 		if err := b.Build(); err != nil {
 			return fmt.Errorf("building site: %w", err)
 		}
+		printWarnings(b.Warnings())
 		fmt.Println("Site built successfully!")
 	}
 
@@ -3,8 +3,10 @@ module github.com/jeroendee/ssg
 go 1.25
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/spf13/cobra v1.10.1
 	github.com/yuin/goldmark v1.7.13
+	golang.org/x/net v0.50.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -1,15 +1,20 @@
 package builder
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/jeroendee/ssg/internal/assets"
@@ -27,11 +32,13 @@ type Builder struct {
 	cfg       *model.Config
 	assetsDir string
 	version   string
+	now       func() time.Time
+	warnings  []string
 }
 
 // New creates a new Builder with the given configuration.
 func New(cfg *model.Config) *Builder {
-	return &Builder{cfg: cfg}
+	return &Builder{cfg: cfg, now: time.Now}
 }
 
 // SetAssetsDir sets the assets directory for static files.
@@ -44,23 +51,36 @@ func (b *Builder) SetVersion(version string) {
 	b.version = version
 }
 
+// Warnings returns the problems found by the last scan that did not fail
+// it, such as math kept as text, each prefixed with the path of its file.
+func (b *Builder) Warnings() []string {
+	return b.warnings
+}
+
 // ScanContent scans the content directory and returns a Site with all pages and posts.
 func (b *Builder) ScanContent() (*model.Site, error) {
+	b.warnings = nil
 	description := b.cfg.Description
 	if description == "" {
 		description = b.cfg.Title
 	}
 
 	site := &model.Site{
-		Title:       b.cfg.Title,
-		Description: description,
-		BaseURL:     b.cfg.BaseURL,
-		Author:      b.cfg.Author,
-		Logo:        b.cfg.Logo,
-		OGImage:     b.cfg.OGImage,
-		Favicon:     b.cfg.Favicon,
-		Navigation:  b.cfg.Navigation,
-		Analytics:   b.cfg.Analytics,
+		Title:           b.cfg.Title,
+		Description:     description,
+		BaseURL:         b.cfg.BaseURL,
+		Author:          b.cfg.Author,
+		Logo:            b.cfg.Logo,
+		OGImage:         b.cfg.OGImage,
+		Favicon:         b.cfg.Favicon,
+		Navigation:      b.cfg.Navigation,
+		Analytics:       b.cfg.Analytics,
+		FeedFormats:     b.cfg.FeedFormats,
+		FeedLimit:       b.cfg.FeedLimit,
+		FeedSummaryOnly: b.cfg.FeedSummaryOnly,
+		FeedExcerpts:    b.cfg.FeedExcerpts,
+		Podcast:         b.cfg.Podcast,
+		FeedPages:       b.cfg.FeedPages,
 	}
 
 	// Check if content directory exists
@@ -92,23 +112,36 @@ func (b *Builder) ScanContent() (*model.Site, error) {
 		}
 
 		path := filepath.Join(b.cfg.ContentDir, entry.Name())
-		page, err := parser.ParsePage(path)
+		page, err := parser.ParsePageWithOptions(path, b.parserOptions())
 		if err != nil {
 			return nil, err
 		}
+		if !page.NoIndex && !page.Draft {
+			page.MarkdownPath = page.Path + "index.md"
+		}
+		b.warnings = append(b.warnings, page.Warnings...)
 		site.Pages = append(site.Pages, *page)
 	}
 
-	// Extract topics for configured pages
+	// Move older months of configured pages to monthly archive pages
 	for i := range site.Pages {
-		if !isTopicPage(site.Pages[i].Path, b.cfg.TopicPages) {
-			continue
+		if isListedPage(site.Pages[i].Path, b.cfg.ArchivePages) {
+			markArchivedMonths(&site.Pages[i])
 		}
-		body, err := readMarkdownBody(b.cfg.ContentDir, site.Pages[i].Slug)
-		if err != nil {
-			return nil, fmt.Errorf("reading markdown for topics: %w", err)
+	}
+
+	// Add calendar heatmaps to pages with date sections
+	for i := range site.Pages {
+		if len(site.Pages[i].DateAnchors) > 0 {
+			site.Pages[i].Calendar = model.Calendar(dateSections(*site, site.Pages[i], nil))
+		}
+	}
+
+	// Add "on this day" pages to configured pages with date sections
+	for i := range site.Pages {
+		if len(site.Pages[i].DateAnchors) > 0 && isListedPage(site.Pages[i].Path, b.cfg.OnThisDayPages) {
+			site.Pages[i].OnThisDayPath = site.Pages[i].Path + model.OnThisDayDir
 		}
-		site.Pages[i].Topics = topics.Extract(body)
 	}
 
 	// Scan for posts (markdown files in blog/ subdirectory)
@@ -131,10 +164,14 @@ func (b *Builder) ScanContent() (*model.Site, error) {
 			}
 
 			path := filepath.Join(blogDir, entry.Name())
-			post, err := parser.ParsePost(path)
+			post, err := parser.ParsePostWithOptions(path, b.parserOptions())
 			if err != nil {
 				return nil, err
 			}
+			if !post.NoIndex && !post.Draft {
+				post.MarkdownPath = post.Path + "index.md"
+			}
+			b.warnings = append(b.warnings, post.Warnings...)
 			site.Posts = append(site.Posts, *post)
 		}
 	}
@@ -144,6 +181,18 @@ func (b *Builder) ScanContent() (*model.Site, error) {
 		return site.Posts[i].Date.After(site.Posts[j].Date)
 	})
 
+	// Extract topics for configured pages, weighted against the whole site
+	if err := b.extractTopics(site); err != nil {
+		return nil, err
+	}
+
+	// Resolve wiki links now that all pages and posts are known
+	if b.cfg.WikiLinks {
+		if err := resolveWikiLinks(site); err != nil {
+			return nil, err
+		}
+	}
+
 	// Load optional footer content from _footer.md
 	footerPath := filepath.Join(b.cfg.ContentDir, "_footer.md")
 	if _, err := os.Stat(footerPath); err == nil {
@@ -161,9 +210,118 @@ func (b *Builder) ScanContent() (*model.Site, error) {
 	return site, nil
 }
 
-// isTopicPage checks if a page path is in the configured topic pages list.
-func isTopicPage(pagePath string, topicPages []string) bool {
-	for _, tp := range topicPages {
+// parserOptions returns the markdown rendering options from the configuration.
+func (b *Builder) parserOptions() parser.Options {
+	return parser.Options{
+		Figures:        b.cfg.Figures,
+		WikiLinks:      b.cfg.WikiLinks,
+		Math:           b.cfg.Math,
+		AssetsDir:      b.assetsDir,
+		ContentDir:     b.cfg.ContentDir,
+		WordsPerMinute: b.cfg.WordsPerMinute,
+	}
+}
+
+// topicSettings returns the topic extraction settings configured for the
+// page at pagePath.
+func (b *Builder) topicSettings(pagePath string) model.TopicSettings {
+	for path, settings := range b.cfg.TopicSettings {
+		if isListedPage(pagePath, []string{path}) {
+			return settings
+		}
+	}
+	return model.TopicSettings{}
+}
+
+// extractTopics extracts the topics of the pages listed in topics.pages,
+// and of blog posts when topics.posts is set. Pages and posts in TF-IDF mode
+// are weighted against the text of all other pages and posts.
+func (b *Builder) extractTopics(site *model.Site) error {
+	stopWords, err := b.topicStopWords()
+	if err != nil {
+		return err
+	}
+
+	var texts []string // Plain text of all pages, then all posts
+	corpus := func(skip int) []string {
+		if texts == nil {
+			for _, page := range site.Pages {
+				texts = append(texts, page.Text)
+			}
+			for _, post := range site.Posts {
+				texts = append(texts, post.Text)
+			}
+		}
+		return append(append([]string(nil), texts[:skip]...), texts[skip+1:]...)
+	}
+
+	for i := range site.Pages {
+		if !isListedPage(site.Pages[i].Path, b.cfg.TopicPages) {
+			continue
+		}
+		settings := b.topicSettings(site.Pages[i].Path)
+		settings.Lang = cmp.Or(site.Pages[i].Lang, settings.Lang)
+		settings.StopWords = stopWords
+
+		var others []string
+		if settings.Mode == topics.ModeTFIDF {
+			others = corpus(i)
+		}
+
+		site.Pages[i].Topics = topics.ExtractWithCorpus(site.Pages[i].Text, others, settings)
+		for j := range site.Pages[i].Topics {
+			site.Pages[i].Topics[j].Path = model.TopicPath(site.Pages[i].Path, site.Pages[i].Topics[j].Word)
+		}
+	}
+
+	if b.cfg.TopicPosts == nil {
+		return nil
+	}
+	// A content page at /topics/ replaces the topic index, so post topics
+	// are not linked there.
+	linkIndex := findPageByPath(site.Pages, model.TopicIndexPath) == nil
+	for i := range site.Posts {
+		settings := *b.cfg.TopicPosts
+		settings.Lang = cmp.Or(site.Posts[i].Lang, settings.Lang)
+		settings.StopWords = stopWords
+
+		var others []string
+		if settings.Mode == topics.ModeTFIDF {
+			others = corpus(len(site.Pages) + i)
+		}
+
+		site.Posts[i].Topics = topics.ExtractWithCorpus(site.Posts[i].Text, others, settings)
+		if !linkIndex {
+			continue
+		}
+		for j := range site.Posts[i].Topics {
+			site.Posts[i].Topics[j].Path = model.TopicIndexPath + "#" + model.TagSlug(site.Posts[i].Topics[j].Word)
+		}
+	}
+	return nil
+}
+
+// topicStopWords reads the extra stop words of topics.stopWordsFile, if set.
+func (b *Builder) topicStopWords() ([]string, error) {
+	if b.cfg.TopicStopWords == "" {
+		return nil, nil
+	}
+	f, err := os.Open(b.cfg.TopicStopWords)
+	if err != nil {
+		return nil, fmt.Errorf("reading topic stop words: %w", err)
+	}
+	defer f.Close()
+	words, err := topics.ReadStopWords(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading topic stop words: %w", err)
+	}
+	return words, nil
+}
+
+// isListedPage checks if a page path is in a configured list of pages,
+// such as topics.pages or archive.pages.
+func isListedPage(pagePath string, pages []string) bool {
+	for _, tp := range pages {
 		normalized := tp
 		if !strings.HasSuffix(normalized, "/") {
 			normalized += "/"
@@ -178,26 +336,66 @@ func isTopicPage(pagePath string, topicPages []string) bool {
 	return false
 }
 
-// readMarkdownBody reads a markdown file and returns the body after frontmatter extraction.
-func readMarkdownBody(contentDir, slug string) (string, error) {
-	filename := slug + ".md"
-	if slug == "" {
-		filename = "home.md"
+// markArchivedMonths records which date sections of page move to monthly
+// archive pages, and points the page's archive navigation at those pages.
+func markArchivedMonths(page *model.Page) {
+	_, months := parser.SplitArchiveMonths(page.Content)
+	if len(months) == 0 {
+		return
 	}
-	path := filepath.Join(contentDir, filename)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
+
+	page.ArchivedAnchors = make(map[string]string)
+	for _, month := range months {
+		archivePath := model.ArchivePath(page.Path, month.Date)
+		for _, anchor := range month.Anchors {
+			page.ArchivedAnchors[anchor] = archivePath
+		}
 	}
-	content := string(data)
-	// Strip frontmatter
-	if strings.HasPrefix(content, "---") {
-		parts := strings.SplitN(content, "---", 3)
-		if len(parts) >= 3 {
-			return strings.TrimSpace(parts[2]), nil
+
+	years := make([]model.YearGroup, len(page.ArchivedYears))
+	for i, year := range page.ArchivedYears {
+		years[i] = model.YearGroup{Year: year.Year, Months: append([]model.MonthGroup(nil), year.Months...)}
+		for j, month := range years[i].Months {
+			for _, date := range month.Dates {
+				if archivePath, ok := page.ArchivedAnchors[date]; ok {
+					years[i].Months[j].Path = archivePath
+					break
+				}
+			}
 		}
 	}
-	return content, nil
+	page.ArchivedYears = years
+}
+
+// splitArchivePage returns page with only its most recent month, in both its
+// HTML and the markdown of its markdown copy, and a page per older month at
+// <page>/YYYY/MM/. Pages without archived months are returned unchanged.
+func splitArchivePage(page model.Page) (model.Page, []model.Page) {
+	if len(page.ArchivedAnchors) == 0 {
+		return page, nil
+	}
+
+	content, months := parser.SplitArchiveMonths(page.Content)
+	archives := make([]model.Page, len(months))
+	for i, month := range months {
+		archivePath := model.ArchivePath(page.Path, month.Date)
+		archives[i] = model.Page{
+			Title:             page.Title + " - " + month.Date.Format("January 2006"),
+			Slug:              strings.Trim(archivePath, "/"),
+			Content:           month.Content,
+			Path:              archivePath,
+			DateAnchors:       month.Anchors,
+			CurrentMonthDates: month.Anchors,
+			ArchivedYears:     page.ArchivedYears,
+			Calendar:          page.Calendar,
+			NoIndex:           page.NoIndex,
+		}
+	}
+	page.Content = content
+	if page.MarkdownPath != "" {
+		page.Markdown = parser.TrimArchivedMarkdown(page.Markdown, page.ArchivedAnchors)
+	}
+	return page, archives
 }
 
 // isMarkdownFile returns true if the filename has a .md extension.
@@ -230,9 +428,29 @@ func (b *Builder) Build() error {
 	}
 	r.SetVersion(b.version)
 
-	// Render pages with clean URLs
+	// Render pages with clean URLs, splitting off monthly archive pages
+	for _, page := range site.Pages {
+		page, archives := splitArchivePage(page)
+		for _, p := range append([]model.Page{page}, archives...) {
+			if err := b.writePage(r, *site, p); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Render "on this day" pages with their date indexes
+	for _, page := range site.Pages {
+		if page.OnThisDayPath == "" {
+			continue
+		}
+		if err := b.writeOnThisDay(r, *site, page); err != nil {
+			return err
+		}
+	}
+
+	// Render topic pages listing the date sections mentioning each topic
 	for _, page := range site.Pages {
-		if err := b.writePage(r, *site, page); err != nil {
+		if err := b.writeTopicPages(r, *site, page); err != nil {
 			return err
 		}
 	}
@@ -256,11 +474,21 @@ func (b *Builder) Build() error {
 		}
 	}
 
+	// Generate the site-wide topic index
+	if err := b.writeTopicIndex(r, *site); err != nil {
+		return err
+	}
+
 	// Generate RSS feed
 	if err := b.writeFeed(r, *site); err != nil {
 		return err
 	}
 
+	// Generate per-section feeds
+	if err := b.writeSectionFeeds(r, *site); err != nil {
+		return err
+	}
+
 	// Generate 404 page
 	if err := b.write404(r, *site); err != nil {
 		return err
@@ -281,6 +509,11 @@ func (b *Builder) Build() error {
 		return err
 	}
 
+	// Generate llms.txt, and llms-full.txt when enabled
+	if err := b.writeLLMsTxt(*site); err != nil {
+		return err
+	}
+
 	// Generate build.json with timestamp for sync verification
 	if err := b.writeBuildTimestamp(); err != nil {
 		return err
@@ -387,18 +620,21 @@ func (b *Builder) writePage(r *renderer.Renderer, site model.Site, page model.Pa
 		return err
 	}
 
-	// Homepage (empty slug) goes directly to /index.html
-	if page.Slug == "" {
-		return os.WriteFile(filepath.Join(b.cfg.OutputDir, "index.html"), []byte(html), 0644)
-	}
-
-	// Create clean URL directory: /slug/index.html
+	// Homepage (empty slug) goes directly to /index.html; others to a
+	// clean URL directory: /slug/index.html
 	dir := filepath.Join(b.cfg.OutputDir, page.Slug)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
-	return os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644)
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644); err != nil {
+		return err
+	}
+	body, err := b.markdownWikiLinks(site, page.Path, page.Markdown)
+	if err != nil {
+		return err
+	}
+	return b.writeMarkdownCopy(page.MarkdownPath, pageFrontmatter(site, page), body)
 }
 
 // writePost writes a post to its clean URL path.
@@ -417,12 +653,24 @@ func (b *Builder) writePost(r *renderer.Renderer, site model.Site, post model.Po
 		return err
 	}
 
-	return os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644)
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644); err != nil {
+		return err
+	}
+	body, err := b.markdownWikiLinks(site, post.Path, postMarkdown(post))
+	if err != nil {
+		return err
+	}
+	return b.writeMarkdownCopy(post.MarkdownPath, postFrontmatter(site, post), body)
 }
 
 // writeBlogListing writes the blog listing page.
 func (b *Builder) writeBlogListing(r *renderer.Renderer, site model.Site) error {
-	html, err := r.RenderBlogList(site, site.Posts)
+	posts := make([]model.Post, len(site.Posts))
+	for i, post := range site.Posts {
+		posts[i] = publishedPost(post)
+	}
+
+	html, err := r.RenderBlogList(site, posts)
 	if err != nil {
 		return err
 	}
@@ -435,71 +683,344 @@ func (b *Builder) writeBlogListing(r *renderer.Renderer, site model.Site) error
 	return os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644)
 }
 
-// writeFeed writes the RSS feed with posts and configured feed pages.
-func (b *Builder) writeFeed(r *renderer.Renderer, site model.Site) error {
-	feedItems := b.collectFeedItems(site)
-	if len(feedItems) == 0 {
+// dateIndexEntry is a date section in the JSON index of an "on this day" page.
+type dateIndexEntry struct {
+	Date        string `json:"date"`
+	Time        string `json:"time,omitempty"`
+	Title       string `json:"title,omitempty"`
+	URL         string `json:"url"`
+	ContentHTML string `json:"content_html"`
+}
+
+// writeOnThisDay writes the "on this day" page of page for the build date,
+// and a JSON index of all its date sections, newest first, for the page's
+// script to show the viewer's current day.
+func (b *Builder) writeOnThisDay(r *renderer.Renderer, site model.Site, page model.Page) error {
+	sections := dateSections(site, page, b.feedTitleTemplate())
+
+	html, err := r.RenderOnThisDay(site, page, sections, b.now())
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Date.After(sections[j].Date)
+	})
+	index := make([]dateIndexEntry, len(sections))
+	for i, s := range sections {
+		index[i] = dateIndexEntry{
+			Date:        s.Date.Format("2006-01-02"),
+			Title:       s.Title,
+			URL:         s.URL(),
+			ContentHTML: s.Content,
+		}
+		if s.HasTime {
+			index[i].Time = s.Date.Format("15:04")
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(index); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(b.cfg.OutputDir, filepath.FromSlash(page.OnThisDayPath))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "index.json"), buf.Bytes(), 0644)
+}
+
+// writeTopicPages writes a page per topic of page, listing the date
+// sections that mention the topic with a snippet around the mention.
+func (b *Builder) writeTopicPages(r *renderer.Renderer, site model.Site, page model.Page) error {
+	if len(page.Topics) == 0 {
 		return nil
 	}
 
-	xml, err := r.RenderFeed(site, feedItems)
+	sections := dateSections(site, page, b.feedTitleTemplate())
+	texts := make([]string, len(sections))
+	for i, s := range sections {
+		texts[i] = parser.PlainText(s.Content)
+	}
+
+	for _, topic := range page.Topics {
+		var mentions []model.TopicMention
+		for i, s := range sections {
+			if snippet, ok := topics.Snippet(texts[i], topic.Words()); ok {
+				mentions = append(mentions, model.TopicMention{Section: s, Snippet: snippet})
+			}
+		}
+
+		html, err := r.RenderTopic(site, page, topic, mentions)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(b.cfg.OutputDir, filepath.FromSlash(topic.Path))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTopicIndex writes the site-wide topic index at /topics/, unless the
+// site has no topics or a content page already uses that path.
+func (b *Builder) writeTopicIndex(r *renderer.Renderer, site model.Site) error {
+	siteTopics := b.siteTopics(site)
+	if len(siteTopics) == 0 || findPageByPath(site.Pages, model.TopicIndexPath) != nil {
+		return nil
+	}
+
+	html, err := r.RenderTopicIndex(site, siteTopics)
 	if err != nil {
 		return err
 	}
+	dir := filepath.Join(b.cfg.OutputDir, filepath.FromSlash(model.TopicIndexPath))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "index.html"), []byte(html), 0644)
+}
+
+// siteTopics merges the topics of pages and posts by slug into the entries
+// of the site-wide topic index, sorted by word. A page contributes the date
+// sections that mention its topics. Each entry is named after its most
+// frequent word.
+func (b *Builder) siteTopics(site model.Site) []model.SiteTopic {
+	bySlug := make(map[string]*model.SiteTopic)
+	best := make(map[string]int) // Count of the word naming each entry
+	add := func(topic model.Topic) *model.SiteTopic {
+		slug := model.TagSlug(topic.Word)
+		entry := bySlug[slug]
+		if entry == nil {
+			entry = &model.SiteTopic{Slug: slug}
+			bySlug[slug] = entry
+		}
+		entry.Count += topic.Count
+		if topic.Count > best[slug] {
+			entry.Word, best[slug] = topic.Word, topic.Count
+		}
+		return entry
+	}
 
-	// Skip if no items (RenderFeed returns empty string)
-	if xml == "" {
+	for _, page := range site.Pages {
+		if len(page.Topics) == 0 {
+			continue
+		}
+		sections := dateSections(site, page, b.feedTitleTemplate())
+		texts := make([]string, len(sections))
+		for i, s := range sections {
+			texts[i] = parser.PlainText(s.Content)
+		}
+		for _, topic := range page.Topics {
+			entry := add(topic)
+			for i, s := range sections {
+				listed := slices.ContainsFunc(entry.Sections, func(d model.DateSection) bool {
+					return d.URL() == s.URL()
+				})
+				if !listed && len(topics.Mentions(texts[i], topic.Words())) > 0 {
+					entry.Sections = append(entry.Sections, s)
+				}
+			}
+		}
+	}
+	for _, post := range site.Posts {
+		for _, topic := range post.Topics {
+			entry := add(topic)
+			entry.Posts = append(entry.Posts, post)
+		}
+	}
+
+	result := make([]model.SiteTopic, 0, len(bySlug))
+	for _, entry := range bySlug {
+		sort.SliceStable(entry.Sections, func(i, j int) bool {
+			return entry.Sections[i].Date.After(entry.Sections[j].Date)
+		})
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Word < result[j].Word
+	})
+	return result
+}
+
+// writeFeed writes a feed in each configured format with posts and configured feed pages.
+func (b *Builder) writeFeed(r *renderer.Renderer, site model.Site) error {
+	feedItems := b.collectFeedItems(site)
+	if len(feedItems) == 0 {
 		return nil
 	}
 
-	return os.WriteFile(filepath.Join(b.cfg.OutputDir, "feed.xml"), []byte(xml), 0644)
+	for _, link := range site.FeedLinks() {
+		var feed string
+		var err error
+		switch link.Format {
+		case model.FeedAtom:
+			feed, err = r.RenderAtomFeed(site, feedItems)
+		case model.FeedJSON:
+			feed, err = r.RenderJSONFeed(site, feedItems)
+		default:
+			feed, err = r.RenderFeed(site, feedItems)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Skip if no items (renderers return empty string)
+		if feed == "" {
+			continue
+		}
+
+		if err := os.WriteFile(filepath.Join(b.cfg.OutputDir, filepath.FromSlash(link.Href)), []byte(feed), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// collectFeedItems gathers all feed items from posts and configured feed pages.
-func (b *Builder) collectFeedItems(site model.Site) []model.FeedItem {
-	var items []model.FeedItem
+// writeSectionFeeds writes an RSS feed to index.xml in the blog, in each tag
+// section, and in each configured feed page.
+func (b *Builder) writeSectionFeeds(r *renderer.Renderer, site model.Site) error {
+	sections := map[string][]model.FeedItem{
+		"/blog/": postFeedItems(site, site.Posts),
+	}
+	for _, group := range site.TagGroups() {
+		sections[group.TagPath()] = postFeedItems(site, group.Posts)
+	}
+	for _, pagePath := range b.cfg.FeedPages {
+		if page := findPageByPath(site.Pages, pagePath); page != nil {
+			sections[page.Path] = dateSectionFeedItems(site, *page, b.feedTitleTemplate())
+		}
+	}
 
-	// Add posts as FeedItems
-	for i := range site.Posts {
-		items = append(items, model.PostFeedAdapter{
-			Post:    &site.Posts[i],
-			BaseURL: site.BaseURL,
-		})
+	for sectionPath, items := range sections {
+		link, ok := site.SectionFeedLink(sectionPath)
+		if !ok || len(items) == 0 {
+			continue
+		}
+		feed, err := r.RenderSectionFeed(site, link, sortFeedItems(site, items))
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Join(b.cfg.OutputDir, filepath.FromSlash(sectionPath))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, model.SectionFeedFile), []byte(feed), 0644); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+// collectFeedItems gathers all feed items from posts and configured feed pages.
+func (b *Builder) collectFeedItems(site model.Site) []model.FeedItem {
+	items := postFeedItems(site, site.Posts)
+
 	// Add date sections from configured feed pages
 	for _, pagePath := range b.cfg.FeedPages {
 		page := findPageByPath(site.Pages, pagePath)
 		if page == nil {
 			continue
 		}
+		items = append(items, dateSectionFeedItems(site, *page, b.feedTitleTemplate())...)
+	}
 
-		sections := parser.ExtractFeedDateSections(page.Content)
-		for _, section := range sections {
-			date, err := parser.ParseDateFromAnchor(section.Anchor)
-			if err != nil {
-				continue
-			}
+	return sortFeedItems(site, items)
+}
 
-			items = append(items, model.DateSection{
-				PageTitle: page.Title,
-				PagePath:  page.Path,
-				Date:      date,
-				Anchor:    section.Anchor,
-				Content:   section.Content,
-				BaseURL:   site.BaseURL,
-			})
+// postFeedItems returns posts as FeedItems, with asset paths as they are published.
+func postFeedItems(site model.Site, posts []model.Post) []model.FeedItem {
+	var items []model.FeedItem
+	for _, post := range posts {
+		post := publishedPost(post)
+		items = append(items, model.PostFeedAdapter{
+			Post:    &post,
+			BaseURL: site.BaseURL,
+		})
+	}
+	return items
+}
+
+// publishedPost returns post with the asset paths of its content and excerpt
+// as they are published.
+func publishedPost(post model.Post) model.Post {
+	post.Content = rewriteAssetPaths(post.Content)
+	post.Excerpt = rewriteAssetPaths(post.Excerpt)
+	return post
+}
+
+// feedTitleTemplate returns the configured feed.title template, or nil for
+// the default. Invalid templates are rejected when the config is loaded.
+func (b *Builder) feedTitleTemplate() *template.Template {
+	if b.cfg.FeedTitle == "" {
+		return nil
+	}
+	tmpl, err := model.ParseFeedTitle(b.cfg.FeedTitle)
+	if err != nil {
+		return nil
+	}
+	return tmpl
+}
+
+// dateSectionFeedItems returns the date-anchored sections of page as FeedItems,
+// titled with titleTemplate.
+func dateSectionFeedItems(site model.Site, page model.Page, titleTemplate *template.Template) []model.FeedItem {
+	var items []model.FeedItem
+	for _, section := range dateSections(site, page, titleTemplate) {
+		items = append(items, section)
+	}
+	return items
+}
+
+// dateSections returns the date-anchored sections of page, in page order,
+// titled with titleTemplate.
+func dateSections(site model.Site, page model.Page, titleTemplate *template.Template) []model.DateSection {
+	var sections []model.DateSection
+	for _, section := range parser.ExtractFeedDateSections(page.Content) {
+		date, err := parser.ParseDateFromAnchor(section.Anchor)
+		if err != nil {
+			continue
 		}
+
+		sections = append(sections, model.DateSection{
+			PageTitle:     page.Title,
+			PagePath:      page.Path,
+			Date:          date,
+			HasTime:       len(section.Anchor) > len("2006-01-02"),
+			Anchor:        section.Anchor,
+			ArchivePath:   page.ArchivedAnchors[section.Anchor],
+			Content:       section.Content,
+			Title:         section.Title,
+			Words:         section.Words,
+			TitleTemplate: titleTemplate,
+			BaseURL:       site.BaseURL,
+		})
 	}
+	return sections
+}
 
-	// Sort by date descending (newest first)
-	sort.Slice(items, func(i, j int) bool {
+// sortFeedItems sorts items by date descending (newest first) and limits
+// them to the site's feed item limit.
+func sortFeedItems(site model.Site, items []model.FeedItem) []model.FeedItem {
+	sort.SliceStable(items, func(i, j int) bool {
 		return items[i].FeedDate().After(items[j].FeedDate())
 	})
 
-	// Limit to 20 items
-	if len(items) > 20 {
-		items = items[:20]
+	if limit := site.FeedItemLimit(); len(items) > limit {
+		items = items[:limit]
 	}
 
 	return items
@@ -612,11 +1133,87 @@ func (b *Builder) copyPostAssets(post model.Post, outputDir string) error {
 	return nil
 }
 
-// generateRobotsTxt creates robots.txt with sitemap reference.
+// aiCrawlers are the user agents of known AI crawlers, disallowed by
+// robots.blockAI.
+var aiCrawlers = []string{
+	"Amazonbot",
+	"anthropic-ai",
+	"Applebot-Extended",
+	"Bytespider",
+	"CCBot",
+	"ChatGPT-User",
+	"Claude-Web",
+	"ClaudeBot",
+	"cohere-ai",
+	"Diffbot",
+	"FacebookBot",
+	"Google-Extended",
+	"GPTBot",
+	"Meta-ExternalAgent",
+	"OAI-SearchBot",
+	"Omgilibot",
+	"PerplexityBot",
+	"Timpibot",
+}
+
+// generateRobotsTxt creates robots.txt with the configured rules and a
+// sitemap reference, separated by blank lines when there are several groups. All agents are allowed unless a rule for "*" says
+// otherwise. With robots.blockAI, known AI crawlers without a rule of their
+// own are disallowed from the whole site.
 func (b *Builder) generateRobotsTxt() error {
-	content := "User-agent: *\nAllow: /\nSitemap: " + b.cfg.BaseURL + "/sitemap.xml\n"
+	groups := b.cfg.Robots
+	hasDefault := slices.ContainsFunc(groups, func(g model.RobotsGroup) bool {
+		return g.UserAgent == "*"
+	})
+	if !hasDefault {
+		groups = append([]model.RobotsGroup{{UserAgent: "*", Allow: []string{"/"}}}, groups...)
+	}
+
+	var buf strings.Builder
+	for i, group := range groups {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		writeRobotsGroup(&buf, []string{group.UserAgent}, group.Allow, group.Disallow)
+	}
+	grouped := len(groups) > 1
+	if b.cfg.RobotsBlockAI {
+		var agents []string
+		for _, agent := range aiCrawlers {
+			hasRule := slices.ContainsFunc(b.cfg.Robots, func(g model.RobotsGroup) bool {
+				return strings.EqualFold(g.UserAgent, agent)
+			})
+			if !hasRule {
+				agents = append(agents, agent)
+			}
+		}
+		if len(agents) > 0 {
+			buf.WriteString("\n")
+			writeRobotsGroup(&buf, agents, nil, []string{"/"})
+			grouped = true
+		}
+	}
+	// The sitemap stands apart from the groups, except after the lone default
+	if grouped {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("Sitemap: " + b.cfg.BaseURL + "/sitemap.xml\n")
+
 	robotsPath := filepath.Join(b.cfg.OutputDir, "robots.txt")
-	return os.WriteFile(robotsPath, []byte(content), 0644)
+	return os.WriteFile(robotsPath, []byte(buf.String()), 0644)
+}
+
+// writeRobotsGroup writes a robots.txt group of rules for agents.
+func writeRobotsGroup(buf *strings.Builder, agents, allow, disallow []string) {
+	for _, agent := range agents {
+		buf.WriteString("User-agent: " + agent + "\n")
+	}
+	for _, p := range allow {
+		buf.WriteString("Allow: " + p + "\n")
+	}
+	for _, p := range disallow {
+		buf.WriteString("Disallow: " + p + "\n")
+	}
 }
 
 // buildTimestamp represents the JSON structure for build.json.
@@ -639,59 +1236,135 @@ func (b *Builder) writeBuildTimestamp() error {
 	return os.WriteFile(buildPath, data, 0644)
 }
 
+// maxSitemapURLs is the most URLs a sitemap may list under the sitemap
+// protocol. Larger sites get a sitemap index of numbered sitemaps.
+const maxSitemapURLs = 50000
+
 // sitemapURL represents a URL entry in the sitemap.
 type sitemapURL struct {
-	Loc     string `xml:"loc"`
-	LastMod string `xml:"lastmod,omitempty"`
+	Loc     string         `xml:"loc"`
+	LastMod string         `xml:"lastmod,omitempty"`
+	Images  []sitemapImage `xml:"image:image"`
+}
+
+// sitemapImage represents an image on the page of a sitemap URL.
+type sitemapImage struct {
+	Loc string `xml:"image:loc"`
 }
 
 // sitemapURLSet represents the root urlset element.
 type sitemapURLSet struct {
-	XMLName xml.Name     `xml:"urlset"`
-	Xmlns   string       `xml:"xmlns,attr"`
-	URLs    []sitemapURL `xml:"url"`
+	XMLName    xml.Name     `xml:"urlset"`
+	Xmlns      string       `xml:"xmlns,attr"`
+	XmlnsImage string       `xml:"xmlns:image,attr"`
+	URLs       []sitemapURL `xml:"url"`
 }
 
-// generateSitemap creates sitemap.xml with all pages and posts.
+// sitemapIndex represents the root sitemapindex element of a split sitemap.
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+// sitemapRef represents a sitemap listed in a sitemap index.
+type sitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+// generateSitemap creates sitemap.xml with all pages and posts, except
+// noindex pages and paths matching sitemap.exclude. Posts list the images
+// among their assets.
 func (b *Builder) generateSitemap(site model.Site) error {
 	var urls []sitemapURL
 
-	// Add pages (without lastmod)
+	// Add pages and their monthly archive pages (without lastmod)
+	var pages []model.Page
 	for _, page := range site.Pages {
-		var loc string
-		if page.Slug == "" {
-			// Homepage: use baseURL/ without double slashes
-			loc = site.BaseURL + "/"
-		} else {
-			loc = site.BaseURL + "/" + page.Slug + "/"
+		page, archives := splitArchivePage(page)
+		pages = append(pages, page)
+		pages = append(pages, archives...)
+	}
+	for _, page := range pages {
+		// Homepage: use baseURL/ without double slashes
+		sitePath := "/"
+		if page.Slug != "" {
+			sitePath = "/" + page.Slug + "/"
+		}
+		if page.NoIndex || b.excludedFromSitemap(sitePath) {
+			continue
 		}
 		urls = append(urls, sitemapURL{
-			Loc: loc,
+			Loc: site.BaseURL + sitePath,
 		})
 	}
 
 	// Add posts (with lastmod using post date)
 	for _, post := range site.Posts {
-		urls = append(urls, sitemapURL{
-			Loc:     site.BaseURL + "/blog/" + post.Slug + "/",
+		sitePath := "/blog/" + post.Slug + "/"
+		if post.NoIndex || b.excludedFromSitemap(sitePath) {
+			continue
+		}
+		u := sitemapURL{
+			Loc:     site.BaseURL + sitePath,
 			LastMod: post.Date.Format("2006-01-02"),
-		})
+		}
+		for _, asset := range post.Assets {
+			if strings.HasPrefix(mime.TypeByExtension(filepath.Ext(asset)), "image/") {
+				u.Images = append(u.Images, sitemapImage{Loc: site.BaseURL + sitePath + filepath.Base(asset)})
+			}
+		}
+		urls = append(urls, u)
 	}
 
-	urlset := sitemapURLSet{
-		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
-		URLs:  urls,
+	return b.writeSitemaps(urls, maxSitemapURLs)
+}
+
+// excludedFromSitemap reports whether sitePath matches a sitemap.exclude glob.
+func (b *Builder) excludedFromSitemap(sitePath string) bool {
+	return slices.ContainsFunc(b.cfg.SitemapExclude, func(pattern string) bool {
+		return model.MatchPath(pattern, sitePath)
+	})
+}
+
+// writeSitemaps writes urls to sitemap.xml. When there are more than limit
+// URLs, they are split over sitemap-1.xml, sitemap-2.xml and so on, and
+// sitemap.xml becomes the index of those sitemaps.
+func (b *Builder) writeSitemaps(urls []sitemapURL, limit int) error {
+	newURLSet := func(urls []sitemapURL) sitemapURLSet {
+		return sitemapURLSet{
+			Xmlns:      "http://www.sitemaps.org/schemas/sitemap/0.9",
+			XmlnsImage: "http://www.google.com/schemas/sitemap-image/1.1",
+			URLs:       urls,
+		}
+	}
+	sitemapPath := filepath.Join(b.cfg.OutputDir, "sitemap.xml")
+	if len(urls) <= limit {
+		return writeXML(sitemapPath, newURLSet(urls))
 	}
 
+	index := sitemapIndex{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	n := 0
+	for chunk := range slices.Chunk(urls, limit) {
+		n++
+		name := fmt.Sprintf("sitemap-%d.xml", n)
+		if err := writeXML(filepath.Join(b.cfg.OutputDir, name), newURLSet(chunk)); err != nil {
+			return err
+		}
+		index.Sitemaps = append(index.Sitemaps, sitemapRef{Loc: b.cfg.BaseURL + "/" + name})
+	}
+	return writeXML(sitemapPath, index)
+}
+
+// writeXML writes v as an indented XML document to path.
+func writeXML(path string, v any) error {
 	var buf strings.Builder
 	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
 
 	enc := xml.NewEncoder(&buf)
 	enc.Indent("", "  ")
-	if err := enc.Encode(urlset); err != nil {
+	if err := enc.Encode(v); err != nil {
 		return err
 	}
-
-	sitemapPath := filepath.Join(b.cfg.OutputDir, "sitemap.xml")
-	return os.WriteFile(sitemapPath, []byte(buf.String()), 0644)
+	return os.WriteFile(path, []byte(buf.String()), 0644)
 }
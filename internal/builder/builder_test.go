@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/png"
 	"os"
 	"path/filepath"
 	"strings"
@@ -642,6 +644,58 @@ func TestBuild_GeneratesRSSFeed(t *testing.T) {
 	}
 }
 
+func TestBuild_GeneratesConfiguredFeedFormats(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	outputDir := t.TempDir()
+
+	os.MkdirAll(filepath.Join(contentDir, "blog"), 0755)
+	writeFile(t, filepath.Join(contentDir, "blog", "2021-03-26-first-post.md"),
+		"---\ntitle: First Post\n---\nFirst content")
+
+	cfg := &model.Config{
+		Title:       "Test Site",
+		BaseURL:     "https://example.com",
+		ContentDir:  contentDir,
+		OutputDir:   outputDir,
+		FeedFormats: []string{model.FeedAtom, model.FeedJSON},
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	atom, err := os.ReadFile(filepath.Join(outputDir, "atom.xml"))
+	if err != nil {
+		t.Fatalf("failed to read atom.xml: %v", err)
+	}
+	if !strings.Contains(string(atom), "<title>First Post</title>") {
+		t.Errorf("atom.xml does not contain post title:\n%s", atom)
+	}
+
+	jsonFeed, err := os.ReadFile(filepath.Join(outputDir, "feed.json"))
+	if err != nil {
+		t.Fatalf("failed to read feed.json: %v", err)
+	}
+	if !strings.Contains(string(jsonFeed), `"title": "First Post"`) {
+		t.Errorf("feed.json does not contain post title:\n%s", jsonFeed)
+	}
+
+	if _, err := os.Stat(filepath.Join(outputDir, "feed.xml")); !os.IsNotExist(err) {
+		t.Error("Build() should not create feed.xml when rss is not configured")
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "blog", "first-post", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read post HTML: %v", err)
+	}
+	if !strings.Contains(string(html), `href="/atom.xml"`) || !strings.Contains(string(html), `href="/feed.json"`) {
+		t.Error("post HTML should advertise the configured feeds")
+	}
+}
+
 func TestBuild_SkipsFeedWhenNoPosts(t *testing.T) {
 	t.Parallel()
 
@@ -704,6 +758,87 @@ func TestBuild_GeneratesRobotsTxt(t *testing.T) {
 	}
 }
 
+func TestBuild_RobotsTxtRules(t *testing.T) {
+	t.Parallel()
+
+	// aiGroup is the robots.txt group blocking the AI crawlers but skip.
+	aiGroup := func(skip string) string {
+		var group strings.Builder
+		for _, agent := range aiCrawlers {
+			if agent != skip {
+				group.WriteString("User-agent: " + agent + "\n")
+			}
+		}
+		return group.String() + "Disallow: /\n"
+	}
+
+	tests := []struct {
+		name    string
+		robots  []model.RobotsGroup
+		blockAI bool
+		want    string
+	}{
+		{
+			name:   "rules keep the default group",
+			robots: []model.RobotsGroup{{UserAgent: "Googlebot", Disallow: []string{"/private/"}}},
+			want: "User-agent: *\nAllow: /\n\n" +
+				"User-agent: Googlebot\nDisallow: /private/\n\n" +
+				"Sitemap: https://example.com/sitemap.xml\n",
+		},
+		{
+			name:   "rule for all agents replaces the default",
+			robots: []model.RobotsGroup{{UserAgent: "*", Allow: []string{"/"}, Disallow: []string{"/drafts/"}}},
+			want: "User-agent: *\nAllow: /\nDisallow: /drafts/\n" +
+				"Sitemap: https://example.com/sitemap.xml\n",
+		},
+		{
+			name:    "block AI crawlers",
+			blockAI: true,
+			want: "User-agent: *\nAllow: /\n\n" +
+				aiGroup("") + "\n" +
+				"Sitemap: https://example.com/sitemap.xml\n",
+		},
+		{
+			name:    "own rules win over the AI block",
+			robots:  []model.RobotsGroup{{UserAgent: "gptbot", Allow: []string{"/blog/"}}},
+			blockAI: true,
+			want: "User-agent: *\nAllow: /\n\n" +
+				"User-agent: gptbot\nAllow: /blog/\n\n" +
+				aiGroup("GPTBot") + "\n" +
+				"Sitemap: https://example.com/sitemap.xml\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			contentDir := t.TempDir()
+			writeHomeMd(t, contentDir)
+			outputDir := t.TempDir()
+			cfg := &model.Config{
+				Title:         "Test Site",
+				BaseURL:       "https://example.com",
+				ContentDir:    contentDir,
+				OutputDir:     outputDir,
+				Robots:        tt.robots,
+				RobotsBlockAI: tt.blockAI,
+			}
+			if err := New(cfg).Build(); err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(outputDir, "robots.txt"))
+			if err != nil {
+				t.Fatalf("failed to read robots.txt: %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("robots.txt content = %q, want %q", content, tt.want)
+			}
+		})
+	}
+}
+
 func TestBuild_GeneratesSitemap(t *testing.T) {
 	t.Parallel()
 
@@ -828,6 +963,358 @@ func TestBuild_SitemapHomepageURL(t *testing.T) {
 	}
 }
 
+func TestBuild_SitemapSkipsNoIndex(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	writeFile(t, filepath.Join(contentDir, "about.md"), "---\ntitle: About\n---\nAbout content")
+	writeFile(t, filepath.Join(contentDir, "drafts.md"), "---\ntitle: Drafts\nnoindex: true\n---\nHidden")
+	if err := os.MkdirAll(filepath.Join(contentDir, "blog"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-01-15-private.md"), "---\ntitle: Private\nnoindex: true\n---\nHidden post")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+	}
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	sitemap, err := os.ReadFile(filepath.Join(outputDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+	if !strings.Contains(string(sitemap), "https://example.com/about/") {
+		t.Error("sitemap.xml missing about page URL")
+	}
+	for _, hidden := range []string{"/drafts/", "/blog/private/"} {
+		if strings.Contains(string(sitemap), hidden) {
+			t.Errorf("sitemap.xml should not list noindex page %s", hidden)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(outputDir, "drafts", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read noindex page: %v", err)
+	}
+	if !strings.Contains(string(page), `<meta name="robots" content="noindex">`) {
+		t.Error("noindex page should still be built with a robots meta tag")
+	}
+}
+
+func TestBuild_SitemapExcludeAndImages(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	writeFile(t, filepath.Join(contentDir, "about.md"), "---\ntitle: About\n---\nAbout content")
+	writeFile(t, filepath.Join(contentDir, "legal.md"), "---\ntitle: Legal\n---\nLegal content")
+	if err := os.MkdirAll(filepath.Join(contentDir, "blog", "assets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(contentDir, "blog", "assets", "photo.png"), "png")
+	writeFile(t, filepath.Join(contentDir, "blog", "assets", "episode.mp3"), "mp3")
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-01-15-photos.md"),
+		"---\ntitle: Photos\naudio: assets/episode.mp3\n---\n![A photo](assets/photo.png)")
+
+	cfg := &model.Config{
+		Title:          "Test Site",
+		BaseURL:        "https://example.com",
+		ContentDir:     contentDir,
+		OutputDir:      outputDir,
+		SitemapExclude: []string{"/legal/"},
+	}
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	sitemap, err := os.ReadFile(filepath.Join(outputDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+	got := string(sitemap)
+	if !strings.Contains(got, "https://example.com/about/") {
+		t.Error("sitemap.xml missing about page URL")
+	}
+	if strings.Contains(got, "/legal/") {
+		t.Error("sitemap.xml should not list excluded page /legal/")
+	}
+	if !strings.Contains(got, `xmlns:image="http://www.google.com/schemas/sitemap-image/1.1"`) {
+		t.Error("sitemap.xml missing image namespace")
+	}
+	if !strings.Contains(got, "<image:image>\n      <image:loc>https://example.com/blog/photos/photo.png</image:loc>") {
+		t.Errorf("sitemap.xml missing post image entry, got:\n%s", got)
+	}
+	if strings.Contains(got, "episode.mp3") {
+		t.Error("sitemap.xml should only list image assets")
+	}
+}
+
+func TestWriteSitemaps_SplitsIntoIndex(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	b := New(&model.Config{BaseURL: "https://example.com", OutputDir: outputDir})
+	urls := []sitemapURL{
+		{Loc: "https://example.com/a/"},
+		{Loc: "https://example.com/b/"},
+		{Loc: "https://example.com/c/"},
+	}
+	if err := b.writeSitemaps(urls, 2); err != nil {
+		t.Fatalf("writeSitemaps() error = %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+	for _, want := range []string{"<sitemapindex", "<loc>https://example.com/sitemap-1.xml</loc>", "<loc>https://example.com/sitemap-2.xml</loc>"} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("sitemap index missing %q, got:\n%s", want, index)
+		}
+	}
+
+	for name, want := range map[string][]string{
+		"sitemap-1.xml": {"/a/", "/b/"},
+		"sitemap-2.xml": {"/c/"},
+	} {
+		content, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if n := strings.Count(string(content), "<url>"); n != len(want) {
+			t.Errorf("%s lists %d URLs, want %d", name, n, len(want))
+		}
+		for _, path := range want {
+			if !strings.Contains(string(content), "https://example.com"+path) {
+				t.Errorf("%s missing %s", name, path)
+			}
+		}
+	}
+}
+
+func TestBuild_LLMsTxtAndMarkdownCopies(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	writeFile(t, filepath.Join(contentDir, "about.md"), "+++\ntitle = \"About [us]\"\ndescription = \"Who we are\"\n+++\n# About\n\nAbout content")
+	writeFile(t, filepath.Join(contentDir, "hidden.md"), "---\ntitle: Hidden\nnoindex: true\n---\nHidden content")
+	writeFile(t, filepath.Join(contentDir, "wip.md"), "---\ntitle: Work in progress\ndraft: true\n---\nDraft content")
+	if err := os.MkdirAll(filepath.Join(contentDir, "blog", "assets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(contentDir, "blog", "assets", "photo.png"), "png")
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-01-15-hello.md"),
+		"---\ntitle: Hello\ntags: [go]\n---\nFirst paragraph.\n\n![A photo](assets/photo.png)")
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-02-01-unfinished.md"), "---\ntitle: Unfinished\ndraft: true\n---\nNot yet")
+
+	cfg := &model.Config{
+		Title:       "Test Site",
+		Description: "A test site",
+		BaseURL:     "https://example.com",
+		Author:      "Jane",
+		ContentDir:  contentDir,
+		OutputDir:   outputDir,
+		LLMsFull:    true,
+	}
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return string(content)
+	}
+
+	llms := read("llms.txt")
+	for _, want := range []string{
+		"# Test Site\n\n> A test site\n",
+		"## Pages\n",
+		"- [About \\[us\\]](https://example.com/about/index.md): Who we are\n",
+		"## Blog\n\n- [Hello](https://example.com/blog/hello/index.md): First paragraph.\n",
+	} {
+		if !strings.Contains(llms, want) {
+			t.Errorf("llms.txt missing %q, got:\n%s", want, llms)
+		}
+	}
+	for _, left := range []string{"Hidden", "Work in progress", "Unfinished"} {
+		if strings.Contains(llms, left) {
+			t.Errorf("llms.txt should leave out %q", left)
+		}
+	}
+
+	wantAbout := "---\ntitle: About [us]\ndescription: Who we are\nurl: https://example.com/about/\n---\n\n# About\n\nAbout content\n"
+	if got := read("about/index.md"); got != wantAbout {
+		t.Errorf("about/index.md = %q, want %q", got, wantAbout)
+	}
+	post := read("blog/hello/index.md")
+	for _, want := range []string{"date: \"2024-01-15\"\n", "author: Jane\n", "tags:\n    - go\n", "![A photo](photo.png)"} {
+		if !strings.Contains(post, want) {
+			t.Errorf("blog/hello/index.md missing %q, got:\n%s", want, post)
+		}
+	}
+	if !strings.Contains(read("about/index.html"), `<link rel="alternate" type="text/markdown" href="/about/index.md">`) {
+		t.Error("about/index.html missing markdown alternate link")
+	}
+
+	for _, missing := range []string{"hidden/index.md", "wip/index.md", "blog/unfinished/index.md"} {
+		if _, err := os.Stat(filepath.Join(outputDir, missing)); !os.IsNotExist(err) {
+			t.Errorf("%s should not be written", missing)
+		}
+	}
+	for _, page := range []string{"hidden/index.html", "wip/index.html", "blog/unfinished/index.html"} {
+		if strings.Contains(read(page), "text/markdown") {
+			t.Errorf("%s should not link a markdown copy", page)
+		}
+	}
+
+	full := read("llms-full.txt")
+	if !strings.Contains(full, wantAbout) || !strings.Contains(full, "![A photo](photo.png)") {
+		t.Errorf("llms-full.txt missing markdown copies, got:\n%s", full)
+	}
+	if strings.Contains(full, "Hidden content") || strings.Contains(full, "Draft content") {
+		t.Error("llms-full.txt should leave out noindex pages and drafts")
+	}
+}
+
+func TestBuild_ArchivedPageMarkdownCopy(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	writeFile(t, filepath.Join(contentDir, "moments.md"),
+		"---\ntitle: Moments\n---\nIntro.\n\n"+
+			"## *2026-03-02*\n\nMarch moment.\n\n"+
+			"## *2026-02-10*\n\nFebruary moment.\n\n"+
+			"## *2026-03-01*\n\nAnother March moment.\n")
+
+	cfg := &model.Config{
+		Title:        "Test Site",
+		BaseURL:      "https://example.com",
+		ContentDir:   contentDir,
+		OutputDir:    outputDir,
+		ArchivePages: []string{"/moments/"},
+		LLMsFull:     true,
+	}
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	md, err := os.ReadFile(filepath.Join(outputDir, "moments", "index.md"))
+	if err != nil {
+		t.Fatalf("failed to read markdown copy: %v", err)
+	}
+	want := "Intro.\n\n## *2026-03-02*\n\nMarch moment.\n\n## *2026-03-01*\n\nAnother March moment.\n"
+	if !strings.HasSuffix(string(md), "---\n\n"+want) {
+		t.Errorf("moments/index.md = %q, want the current month only", md)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "moments", "2026", "02", "index.html")); err != nil {
+		t.Fatalf("February archive page not written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "moments", "2026", "02", "index.md")); !os.IsNotExist(err) {
+		t.Error("monthly archive pages should not get markdown copies")
+	}
+
+	full, err := os.ReadFile(filepath.Join(outputDir, "llms-full.txt"))
+	if err != nil {
+		t.Fatalf("failed to read llms-full.txt: %v", err)
+	}
+	if strings.Contains(string(full), "February moment.") {
+		t.Error("llms-full.txt should hold the trimmed markdown copy")
+	}
+}
+
+func TestBuild_MarkdownCopyWikiLinks(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	writeFile(t, filepath.Join(contentDir, "garden.md"), "---\ntitle: Garden\n---\nRead [[hello|the first post]] or see [[Moments]].")
+	writeFile(t, filepath.Join(contentDir, "moments.md"), "---\ntitle: Moments\n---\nMoments content")
+	if err := os.MkdirAll(filepath.Join(contentDir, "blog"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-01-15-hello.md"), "---\ntitle: Hello\n---\nBack to [[Garden]].")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		WikiLinks:  true,
+		LLMsFull:   true,
+	}
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{file: "garden/index.md", want: []string{"Read [the first post](/blog/hello/) or see [Moments](/moments/)."}},
+		{file: "blog/hello/index.md", want: []string{"Back to [Garden](/garden/)."}},
+		{file: "llms-full.txt", want: []string{"[the first post](/blog/hello/)", "Back to [Garden](/garden/)."}},
+	}
+	for _, tt := range tests {
+		content, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(tt.file)))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", tt.file, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s missing %q, got:\n%s", tt.file, want, content)
+			}
+		}
+		if strings.Contains(string(content), "[[") {
+			t.Errorf("%s should not contain wiki link syntax, got:\n%s", tt.file, content)
+		}
+	}
+}
+
+func TestBuild_Warnings(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	if err := os.MkdirAll(filepath.Join(contentDir, "blog"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	post := filepath.Join(contentDir, "blog", "2026-01-02-math.md")
+	writeFile(t, post, "---\ntitle: Math\n---\nBad $\\nosuchcommand$ math.\n")
+
+	b := New(&model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		Math:       true,
+	})
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	warnings := b.Warnings()
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], post+`: math "$\\nosuchcommand$" kept as text`) {
+		t.Errorf("Warnings() = %q, want the unconverted math of %s", warnings, post)
+	}
+}
+
 func TestBuild_SitemapWithNoPosts(t *testing.T) {
 	t.Parallel()
 
@@ -1624,23 +2111,264 @@ Here is an image:
 	}
 }
 
-func TestCollectFeedItems_PostsOnly(t *testing.T) {
+func TestBuild_FiguresWithPostAssets(t *testing.T) {
 	t.Parallel()
 
 	contentDir := t.TempDir()
+	outputDir := t.TempDir()
 	writeHomeMd(t, contentDir)
 
-	// Create blog posts
-	blogDir := filepath.Join(contentDir, "blog")
-	os.MkdirAll(blogDir, 0755)
-	writeFile(t, filepath.Join(blogDir, "2026-01-27-post1.md"), "---\ntitle: Post 1\n---\nContent 1")
-	writeFile(t, filepath.Join(blogDir, "2026-01-26-post2.md"), "---\ntitle: Post 2\n---\nContent 2")
+	assetsDir := filepath.Join(contentDir, "blog", "assets")
+	if err := os.MkdirAll(assetsDir, 0755); err != nil {
+		t.Fatalf("failed to create assets dir: %v", err)
+	}
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 120, 80))); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	writeFile(t, filepath.Join(assetsDir, "photo.png"), img.String())
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-01-01-photo.md"), "---\ntitle: Photo\n---\n![A photo](assets/photo.png \"Sunset\")\n")
 
 	cfg := &model.Config{
 		Title:      "Test Site",
 		BaseURL:    "https://example.com",
 		ContentDir: contentDir,
-		FeedPages:  []string{}, // No feed pages configured
+		OutputDir:  outputDir,
+		Figures:    true,
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "blog", "photo", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read HTML: %v", err)
+	}
+	want := `<img src="photo.png" alt="A photo" loading="lazy" decoding="async" width="120" height="80">`
+	if !strings.Contains(string(html), want) {
+		t.Errorf("HTML should contain %s, got:\n%s", want, html)
+	}
+	if !strings.Contains(string(html), "<figcaption>Sunset</figcaption>") {
+		t.Errorf("HTML should contain figcaption, got:\n%s", html)
+	}
+}
+
+func TestBuild_ExpandsIncludes(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	contentDir := filepath.Join(root, "content")
+	outputDir := filepath.Join(root, "public")
+	os.MkdirAll(filepath.Join(contentDir, "_partials"), 0755)
+	os.MkdirAll(filepath.Join(contentDir, "blog"), 0755)
+	writeHomeMd(t, contentDir)
+	writeFile(t, filepath.Join(root, "hello.go"), "package hello\n\nfunc Hello() {}\n")
+	writeFile(t, filepath.Join(contentDir, "_partials", "disclaimer.md"), "*Opinions are my own.*")
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-01-01-code.md"),
+		"---\ntitle: Code\n---\n{{< code \"../hello.go\" 3-3 >}}\n\n{{< include \"disclaimer.md\" >}}\n")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "blog", "code", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read HTML: %v", err)
+	}
+	for _, want := range []string{
+		`<pre><code class="language-go">func Hello() {}`,
+		"<em>Opinions are my own.</em>",
+	} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("HTML should contain %s, got:\n%s", want, html)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "_partials")); !os.IsNotExist(err) {
+		t.Error("partials should not be rendered as pages")
+	}
+}
+
+func TestScanContent_ResolvesWikiLinks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "home.md"), "---\ntitle: Home\n---\nStart at [[Garden]].")
+	writeFile(t, filepath.Join(dir, "garden.md"), "---\ntitle: Garden\n---\nRead [[hello|the first post]] or go [[home|back]].")
+	writeFile(t, filepath.Join(dir, "about.md"), "---\ntitle: About\n---\nSee [[garden]] and [[Garden]].")
+	os.MkdirAll(filepath.Join(dir, "blog"), 0755)
+	writeFile(t, filepath.Join(dir, "blog", "2024-01-01-hello.md"), "---\ntitle: Hello\n---\nBack to [[Garden]].")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: dir,
+		WikiLinks:  true,
+	}
+
+	site, err := New(cfg).ScanContent()
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+
+	garden := findPageByPath(site.Pages, "/garden/")
+	if garden == nil {
+		t.Fatal("garden page not found")
+	}
+	for _, want := range []string{
+		`<a href="/blog/hello/" class="wikilink">the first post</a>`,
+		`<a href="/" class="wikilink">back</a>`,
+	} {
+		if !strings.Contains(garden.Content, want) {
+			t.Errorf("garden Content = %q, want to contain %q", garden.Content, want)
+		}
+	}
+
+	wantBacklinks := []model.Backlink{
+		{Title: "About", URL: "/about/"},
+		{Title: "Hello", URL: "/blog/hello/"},
+		{Title: "Home", URL: "/"},
+	}
+	if len(garden.Backlinks) != len(wantBacklinks) {
+		t.Fatalf("garden Backlinks = %v, want %v", garden.Backlinks, wantBacklinks)
+	}
+	for i, want := range wantBacklinks {
+		if garden.Backlinks[i] != want {
+			t.Errorf("garden Backlinks[%d] = %v, want %v", i, garden.Backlinks[i], want)
+		}
+	}
+
+	if len(site.Posts[0].Backlinks) != 1 || site.Posts[0].Backlinks[0].URL != "/garden/" {
+		t.Errorf("post Backlinks = %v, want link from /garden/", site.Posts[0].Backlinks)
+	}
+}
+
+func TestScanContent_UnresolvedWikiLinkFails(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeHomeMd(t, dir)
+	writeFile(t, filepath.Join(dir, "garden.md"), "---\ntitle: Garden\n---\nSee [[Nowhere]].")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: dir,
+		WikiLinks:  true,
+	}
+
+	_, err := New(cfg).ScanContent()
+	if err == nil {
+		t.Fatal("ScanContent() expected error for unresolved wiki link")
+	}
+	if !strings.Contains(err.Error(), "/garden/") || !strings.Contains(err.Error(), "[[Nowhere]]") {
+		t.Errorf("error = %q, want page path and link target", err)
+	}
+}
+
+func TestScanContent_AmbiguousWikiLinkFails(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeHomeMd(t, dir)
+	writeFile(t, filepath.Join(dir, "notes.md"), "---\ntitle: Notes\n---\nSee [[Duplicate]].")
+	writeFile(t, filepath.Join(dir, "one.md"), "---\ntitle: Duplicate\n---\nOne")
+	writeFile(t, filepath.Join(dir, "two.md"), "---\ntitle: Duplicate\n---\nTwo")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: dir,
+		WikiLinks:  true,
+	}
+
+	_, err := New(cfg).ScanContent()
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("ScanContent() error = %v, want ambiguous wiki link error", err)
+	}
+}
+
+func TestBuild_ExcerptAssetsAndWikiLinks(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+	writeFile(t, filepath.Join(contentDir, "moments.md"), "---\ntitle: Moments\n---\nMoments content")
+	if err := os.MkdirAll(filepath.Join(contentDir, "blog", "assets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(contentDir, "blog", "assets", "photo.png"), "png")
+	writeFile(t, filepath.Join(contentDir, "blog", "2024-01-15-first-post.md"),
+		"---\ntitle: First Post\n---\n![A photo](assets/photo.png)\n\nSee [[Moments]].\n\n<!--more-->\n\nThe rest.")
+
+	cfg := &model.Config{
+		Title:        "Test Site",
+		BaseURL:      "https://example.com",
+		ContentDir:   contentDir,
+		OutputDir:    outputDir,
+		WikiLinks:    true,
+		FeedExcerpts: true,
+	}
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{
+			file: filepath.Join("blog", "index.html"),
+			want: []string{`src="/blog/first-post/photo.png"`, `href="/moments/"`},
+		},
+		{
+			file: "feed.xml",
+			want: []string{`src="https://example.com/blog/first-post/photo.png"`, `href="https://example.com/moments/"`},
+		},
+	}
+	for _, tt := range tests {
+		got, err := os.ReadFile(filepath.Join(outputDir, tt.file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", tt.file, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(got), want) {
+				t.Errorf("%s should contain %s, got:\n%s", tt.file, want, got)
+			}
+		}
+		for _, unwanted := range []string{"assets/photo.png", "wikilink:"} {
+			if strings.Contains(string(got), unwanted) {
+				t.Errorf("%s should not contain %s", tt.file, unwanted)
+			}
+		}
+	}
+}
+
+func TestCollectFeedItems_PostsOnly(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	// Create blog posts
+	blogDir := filepath.Join(contentDir, "blog")
+	os.MkdirAll(blogDir, 0755)
+	writeFile(t, filepath.Join(blogDir, "2026-01-27-post1.md"), "---\ntitle: Post 1\n---\nContent 1")
+	writeFile(t, filepath.Join(blogDir, "2026-01-26-post2.md"), "---\ntitle: Post 2\n---\nContent 2")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		FeedPages:  []string{}, // No feed pages configured
 	}
 
 	b := New(cfg)
@@ -1708,7 +2436,7 @@ Earlier moment.
 	}
 
 	// Verify sorted by date (newest first): 2026-01-28, 2026-01-27, 2026-01-25
-	if !strings.Contains(items[0].FeedTitle(), "January 28, 2026") {
+	if items[0].FeedTitle() != "Moments - Today's moment." {
 		t.Errorf("First item title = %q, expected date section from Jan 28", items[0].FeedTitle())
 	}
 	if items[1].FeedTitle() != "Blog Post" {
@@ -1734,152 +2462,621 @@ func TestCollectFeedItems_NonExistentFeedPage(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ScanContent() error = %v", err)
 	}
-
-	// Should not panic, just skip the missing page
-	items := b.collectFeedItems(*site)
-	if len(items) != 0 {
-		t.Errorf("collectFeedItems() returned %d items, want 0 (no posts, missing feed page)", len(items))
+
+	// Should not panic, just skip the missing page
+	items := b.collectFeedItems(*site)
+	if len(items) != 0 {
+		t.Errorf("collectFeedItems() returned %d items, want 0 (no posts, missing feed page)", len(items))
+	}
+}
+
+func TestCollectFeedItems_LimitsTo20(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	// Create 25 blog posts
+	blogDir := filepath.Join(contentDir, "blog")
+	os.MkdirAll(blogDir, 0755)
+	for i := 1; i <= 25; i++ {
+		filename := fmt.Sprintf("2026-01-%02d-post%d.md", i, i)
+		content := fmt.Sprintf("---\ntitle: Post %d\n---\nContent", i)
+		writeFile(t, filepath.Join(blogDir, filename), content)
+	}
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		FeedPages:  []string{},
+	}
+
+	b := New(cfg)
+	site, err := b.ScanContent()
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+
+	items := b.collectFeedItems(*site)
+	if len(items) != 20 {
+		t.Errorf("collectFeedItems() returned %d items, want 20 (limit)", len(items))
+	}
+}
+
+func TestCollectFeedItems_ConfiguredLimit(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	blogDir := filepath.Join(contentDir, "blog")
+	os.MkdirAll(blogDir, 0755)
+	for i := 1; i <= 5; i++ {
+		filename := fmt.Sprintf("2026-01-%02d-post%d.md", i, i)
+		writeFile(t, filepath.Join(blogDir, filename), fmt.Sprintf("---\ntitle: Post %d\n---\nContent", i))
+	}
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		FeedLimit:  2,
+	}
+
+	b := New(cfg)
+	site, err := b.ScanContent()
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+
+	items := b.collectFeedItems(*site)
+	if len(items) != 2 {
+		t.Fatalf("collectFeedItems() returned %d items, want 2", len(items))
+	}
+	if items[0].FeedTitle() != "Post 5" {
+		t.Errorf("first item = %q, want newest post", items[0].FeedTitle())
+	}
+}
+
+func TestFindPageByPath(t *testing.T) {
+	t.Parallel()
+
+	pages := []Page{
+		{Title: "About", Slug: "about", Path: "/about/"},
+		{Title: "Now", Slug: "now", Path: "/now/"},
+		{Title: "Home", Slug: "", Path: "/"},
+	}
+
+	tests := []struct {
+		path     string
+		wantName string
+		wantNil  bool
+	}{
+		{"/now/", "Now", false},
+		{"now/", "Now", false}, // Missing leading slash
+		{"/now", "Now", false}, // Missing trailing slash
+		{"/about/", "About", false},
+		{"/missing/", "", true},
+		{"/", "Home", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			got := findPageByPath(pages, tt.path)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("findPageByPath(%q) = %v, want nil", tt.path, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Errorf("findPageByPath(%q) = nil, want page with title %q", tt.path, tt.wantName)
+				return
+			}
+			if got.Title != tt.wantName {
+				t.Errorf("findPageByPath(%q) = %q, want %q", tt.path, got.Title, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestBuild_FeedContentUsesAbsoluteURLs(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	writeFile(t, filepath.Join(contentDir, "moments.md"),
+		"---\ntitle: Moments\n---\n## *2026-01-28*\n\nSee [about](/about/) and [yesterday](#2026-01-27).\n")
+
+	assetsDir := filepath.Join(contentDir, "blog", "assets")
+	os.MkdirAll(assetsDir, 0755)
+	writeFile(t, filepath.Join(assetsDir, "photo.jpg"), "jpg")
+	writeFile(t, filepath.Join(contentDir, "blog", "2026-01-27-photo.md"),
+		"---\ntitle: Photo\n---\n![A photo](assets/photo.jpg)\n")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		FeedPages:  []string{"/moments/"},
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	feed, err := os.ReadFile(filepath.Join(outputDir, "feed.xml"))
+	if err != nil {
+		t.Fatalf("failed to read feed.xml: %v", err)
+	}
+	for _, want := range []string{
+		`src="https://example.com/blog/photo/photo.jpg"`,
+		`href="https://example.com/about/"`,
+		`href="https://example.com/moments/#2026-01-27"`,
+	} {
+		if !strings.Contains(string(feed), want) {
+			t.Errorf("feed.xml missing %s:\n%s", want, feed)
+		}
+	}
+
+	// The published post page keeps its relative asset path
+	html, err := os.ReadFile(filepath.Join(outputDir, "blog", "photo", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read post HTML: %v", err)
+	}
+	if !strings.Contains(string(html), `src="photo.jpg"`) {
+		t.Error("post HTML should keep relative asset path")
+	}
+}
+
+func TestBuild_PublishesPostMedia(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	assetsDir := filepath.Join(contentDir, "blog", "assets")
+	os.MkdirAll(assetsDir, 0755)
+	writeFile(t, filepath.Join(assetsDir, "episode-1.mp3"), "0123456789")
+	writeFile(t, filepath.Join(contentDir, "blog", "2026-01-27-episode-1.md"),
+		"---\ntitle: Episode 1\naudio: assets/episode-1.mp3\nduration: \"32:15\"\n---\nShow notes\n")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		Podcast:    &model.Podcast{Category: "Technology"},
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(outputDir, "blog", "episode-1", "episode-1.mp3")); err != nil {
+		t.Errorf("media file not copied: %v", err)
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "blog", "episode-1", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read post HTML: %v", err)
+	}
+	if !strings.Contains(string(html), `<source src="episode-1.mp3" type="audio/mpeg">`) {
+		t.Errorf("post HTML missing audio player:\n%s", html)
+	}
+
+	feed, err := os.ReadFile(filepath.Join(outputDir, "feed.xml"))
+	if err != nil {
+		t.Fatalf("failed to read feed.xml: %v", err)
+	}
+	for _, want := range []string{
+		`<enclosure url="https://example.com/blog/episode-1/episode-1.mp3" length="10" type="audio/mpeg"></enclosure>`,
+		"<itunes:duration>32:15</itunes:duration>",
+	} {
+		if !strings.Contains(string(feed), want) {
+			t.Errorf("feed.xml missing %s:\n%s", want, feed)
+		}
+	}
+}
+
+func TestBuild_WritesSectionFeeds(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	writeFile(t, filepath.Join(contentDir, "now.md"),
+		"---\ntitle: Now\n---\n## *2026-01-28*\n\nReading.\n")
+	os.MkdirAll(filepath.Join(contentDir, "blog"), 0755)
+	writeFile(t, filepath.Join(contentDir, "blog", "2026-01-27-tagged.md"),
+		"---\ntitle: Tagged\ntags: [Go]\n---\nTagged post\n")
+	writeFile(t, filepath.Join(contentDir, "blog", "2026-01-26-untagged.md"),
+		"---\ntitle: Untagged\n---\nUntagged post\n")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		FeedPages:  []string{"/now/"},
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tests := []struct {
+		file    string
+		want    []string
+		notWant []string
+	}{
+		{
+			file:    "blog/index.xml",
+			want:    []string{"/blog/tagged/", "/blog/untagged/"},
+			notWant: []string{"/now/#"},
+		},
+		{
+			file:    "tags/go/index.xml",
+			want:    []string{"<title>Test Site - Tag: Go</title>", "/blog/tagged/"},
+			notWant: []string{"/blog/untagged/"},
+		},
+		{
+			file:    "now/index.xml",
+			want:    []string{"https://example.com/now/#2026-01-28"},
+			notWant: []string{"/blog/"},
+		},
+	}
+	for _, tt := range tests {
+		feed, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(tt.file)))
+		if err != nil {
+			t.Errorf("failed to read %s: %v", tt.file, err)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(feed), want) {
+				t.Errorf("%s missing %q", tt.file, want)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(string(feed), notWant) {
+				t.Errorf("%s should not contain %q", tt.file, notWant)
+			}
+		}
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "blog", "tagged", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if !strings.Contains(string(html), `href="/tags/go/index.xml"`) {
+		t.Error("tagged post should link to its tag feed")
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "tags", "go", "index.html")); !os.IsNotExist(err) {
+		t.Error("tags should get a feed only, not a listing page")
+	}
+}
+
+func TestBuild_FeedIncludesPages(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	writeHomeMd(t, contentDir)
+
+	// Create a feed page with date sections
+	feedPageContent := `---
+title: Moments
+---
+# My Moments
+
+## 2026-01-28
+
+Today's moment.
+`
+	writeFile(t, filepath.Join(contentDir, "moments.md"), feedPageContent)
+
+	// Create blog post
+	blogDir := filepath.Join(contentDir, "blog")
+	os.MkdirAll(blogDir, 0755)
+	writeFile(t, filepath.Join(blogDir, "2026-01-27-post.md"), "---\ntitle: Blog Post\n---\nPost content")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		FeedPages:  []string{"/moments/"},
+	}
+
+	b := New(cfg)
+	err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// Read feed.xml
+	feedPath := filepath.Join(outputDir, "feed.xml")
+	content, err := os.ReadFile(feedPath)
+	if err != nil {
+		t.Fatalf("failed to read feed.xml: %v", err)
+	}
+
+	feedStr := string(content)
+
+	// Check blog post is in feed
+	if !strings.Contains(feedStr, "<title>Blog Post</title>") {
+		t.Error("feed.xml missing blog post")
+	}
+
+	// Check page date section is in feed
+	if !strings.Contains(feedStr, "<title>Moments - Today&#39;s moment.</title>") {
+		t.Error("feed.xml missing page date section")
+	}
+
+	// Check link format for page section
+	if !strings.Contains(feedStr, "https://example.com/moments/#2026-01-28") {
+		t.Error("feed.xml missing page date section link with anchor")
+	}
+}
+
+func TestBuild_FeedTitlesDateSectionsWithTimes(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	writeFile(t, filepath.Join(contentDir, "now.md"),
+		"---\ntitle: Now\n---\n## *2026-01-27 14:30*\n\nShipped the release. Celebrated.\n\n## *2026-01-27 09:05*\n\n### Morning run\n\nTen kilometres.\n")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		FeedPages:  []string{"/now/"},
+		FeedTitle:  "{{.Time}} {{.Title}}",
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	feed, err := os.ReadFile(filepath.Join(outputDir, "feed.xml"))
+	if err != nil {
+		t.Fatalf("failed to read feed.xml: %v", err)
+	}
+	for _, want := range []string{
+		"<title>14:30 Shipped the release.</title>",
+		"<title>09:05 Morning run</title>",
+		"<link>https://example.com/now/#2026-01-27-1430</link>",
+		"<link>https://example.com/now/#2026-01-27-0905</link>",
+		"<pubDate>Tue, 27 Jan 2026 14:30:00 +0000</pubDate>",
+	} {
+		if !strings.Contains(string(feed), want) {
+			t.Errorf("feed.xml missing %s:\n%s", want, feed)
+		}
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "now", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+	if !strings.Contains(string(html), `href="#2026-01-27-1430"`) {
+		t.Error("date navigation should link to timed anchors")
+	}
+}
+
+func TestBuild_ArchivesOlderMonths(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	writeFile(t, filepath.Join(contentDir, "moments.md"),
+		"---\ntitle: Moments\n---\n## *2026-02-03*\n\nFebruary entry.\n\n## *2025-03-14*\n\nMarch entry.\n")
+	writeFile(t, filepath.Join(contentDir, "now.md"),
+		"---\ntitle: Now\n---\n## *2026-02-03*\n\nNow entry.\n\n## *2025-03-14*\n\nOld now entry.\n")
+
+	cfg := &model.Config{
+		Title:        "Test Site",
+		BaseURL:      "https://example.com",
+		ContentDir:   contentDir,
+		OutputDir:    outputDir,
+		FeedPages:    []string{"/moments/"},
+		ArchivePages: []string{"/moments/"},
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	main, err := os.ReadFile(filepath.Join(outputDir, "moments", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+	if !strings.Contains(string(main), "February entry.") || strings.Contains(string(main), "March entry.") {
+		t.Error("page should keep only the current month")
+	}
+	if !strings.Contains(string(main), `href="/moments/2025/03/#2025-03-14"`) {
+		t.Error("archive navigation should link to the archive page")
+	}
+
+	archive, err := os.ReadFile(filepath.Join(outputDir, "moments", "2025", "03", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read archive page: %v", err)
+	}
+	if !strings.Contains(string(archive), "March entry.") || strings.Contains(string(archive), "February entry.") {
+		t.Error("archive page should hold only its month")
+	}
+	if !strings.Contains(string(archive), "<title>Moments - March 2025 - Test Site</title>") {
+		t.Error("archive page should be titled with its month")
+	}
+
+	// Pages not listed in archive.pages are left whole
+	now, err := os.ReadFile(filepath.Join(outputDir, "now", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+	if !strings.Contains(string(now), "Old now entry.") {
+		t.Error("unlisted page should keep all months")
+	}
+
+	feed, err := os.ReadFile(filepath.Join(outputDir, "feed.xml"))
+	if err != nil {
+		t.Fatalf("failed to read feed.xml: %v", err)
+	}
+	for _, want := range []string{
+		"<link>https://example.com/moments/2025/03/#2025-03-14</link>",
+		`<guid isPermaLink="false">https://example.com/moments/#2025-03-14</guid>`,
+	} {
+		if !strings.Contains(string(feed), want) {
+			t.Errorf("feed.xml missing %s", want)
+		}
+	}
+
+	sitemap, err := os.ReadFile(filepath.Join(outputDir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("failed to read sitemap.xml: %v", err)
+	}
+	if !strings.Contains(string(sitemap), "<loc>https://example.com/moments/2025/03/</loc>") {
+		t.Error("sitemap should list the archive page")
 	}
 }
 
-func TestCollectFeedItems_LimitsTo20(t *testing.T) {
+func TestBuild_WritesOnThisDayPage(t *testing.T) {
 	t.Parallel()
 
 	contentDir := t.TempDir()
+	outputDir := t.TempDir()
 	writeHomeMd(t, contentDir)
 
-	// Create 25 blog posts
-	blogDir := filepath.Join(contentDir, "blog")
-	os.MkdirAll(blogDir, 0755)
-	for i := 1; i <= 25; i++ {
-		filename := fmt.Sprintf("2026-01-%02d-post%d.md", i, i)
-		content := fmt.Sprintf("---\ntitle: Post %d\n---\nContent", i)
-		writeFile(t, filepath.Join(blogDir, filename), content)
-	}
+	writeFile(t, filepath.Join(contentDir, "moments.md"),
+		"---\ntitle: Moments\n---\n## *2026-03-01*\n\nRecent.\n\n## *2025-03-14 09:30*\n\nPi day last year.\n\n## *2024-03-14*\n\nPi day before.\n")
+	writeFile(t, filepath.Join(contentDir, "now.md"),
+		"---\ntitle: Now\n---\n## *2025-03-14*\n\nNot listed.\n")
 
 	cfg := &model.Config{
-		Title:      "Test Site",
-		BaseURL:    "https://example.com",
-		ContentDir: contentDir,
-		FeedPages:  []string{},
+		Title:          "Test Site",
+		BaseURL:        "https://example.com",
+		ContentDir:     contentDir,
+		OutputDir:      outputDir,
+		OnThisDayPages: []string{"/moments/", "/now/", "/missing/"},
+		ArchivePages:   []string{"/moments/"},
 	}
 
 	b := New(cfg)
-	site, err := b.ScanContent()
-	if err != nil {
-		t.Fatalf("ScanContent() error = %v", err)
+	b.now = func() time.Time { return time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC) }
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
 	}
 
-	items := b.collectFeedItems(*site)
-	if len(items) != 20 {
-		t.Errorf("collectFeedItems() returned %d items, want 20 (limit)", len(items))
+	html, err := os.ReadFile(filepath.Join(outputDir, "moments", "on-this-day", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read on this day page: %v", err)
 	}
-}
-
-func TestFindPageByPath(t *testing.T) {
-	t.Parallel()
-
-	pages := []Page{
-		{Title: "About", Slug: "about", Path: "/about/"},
-		{Title: "Now", Slug: "now", Path: "/now/"},
-		{Title: "Home", Slug: "", Path: "/"},
+	for _, want := range []string{"Pi day last year.", "Pi day before.", `href="/moments/2025/03/#2025-03-14-0930"`} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("on this day page missing %q", want)
+		}
+	}
+	if strings.Contains(string(html), "Recent.") {
+		t.Error("on this day page should not list other days")
 	}
 
-	tests := []struct {
-		path     string
-		wantName string
-		wantNil  bool
-	}{
-		{"/now/", "Now", false},
-		{"now/", "Now", false}, // Missing leading slash
-		{"/now", "Now", false}, // Missing trailing slash
-		{"/about/", "About", false},
-		{"/missing/", "", true},
-		{"/", "Home", false},
+	data, err := os.ReadFile(filepath.Join(outputDir, "moments", "on-this-day", "index.json"))
+	if err != nil {
+		t.Fatalf("failed to read date index: %v", err)
+	}
+	var index []dateIndexEntry
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("invalid date index: %v", err)
+	}
+	want := []dateIndexEntry{
+		{Date: "2026-03-01", Title: "Recent.", URL: "/moments/#2026-03-01", ContentHTML: "<p>Recent.</p>"},
+		{Date: "2025-03-14", Time: "09:30", Title: "Pi day last year.", URL: "/moments/2025/03/#2025-03-14-0930", ContentHTML: "<p>Pi day last year.</p>"},
+		{Date: "2024-03-14", Title: "Pi day before.", URL: "/moments/2024/03/#2024-03-14", ContentHTML: "<p>Pi day before.</p>"},
+	}
+	if len(index) != len(want) {
+		t.Fatalf("date index has %d entries, want %d: %+v", len(index), len(want), index)
+	}
+	for i := range want {
+		if index[i] != want[i] {
+			t.Errorf("index[%d] = %+v, want %+v", i, index[i], want[i])
+		}
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.path, func(t *testing.T) {
-			t.Parallel()
-			got := findPageByPath(pages, tt.path)
-			if tt.wantNil {
-				if got != nil {
-					t.Errorf("findPageByPath(%q) = %v, want nil", tt.path, got)
-				}
-				return
-			}
-			if got == nil {
-				t.Errorf("findPageByPath(%q) = nil, want page with title %q", tt.path, tt.wantName)
-				return
-			}
-			if got.Title != tt.wantName {
-				t.Errorf("findPageByPath(%q) = %q, want %q", tt.path, got.Title, tt.wantName)
-			}
-		})
+	page, err := os.ReadFile(filepath.Join(outputDir, "moments", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+	if !strings.Contains(string(page), `<a href="/moments/on-this-day/">On this day</a>`) {
+		t.Error("page should link to its on this day page")
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "now", "on-this-day", "index.html")); err != nil {
+		t.Errorf("listed page should get an on this day page: %v", err)
 	}
 }
 
-func TestBuild_FeedIncludesPages(t *testing.T) {
+func TestBuild_CalendarHeatmap(t *testing.T) {
 	t.Parallel()
 
 	contentDir := t.TempDir()
 	outputDir := t.TempDir()
-
 	writeHomeMd(t, contentDir)
 
-	// Create a feed page with date sections
-	feedPageContent := `---
-title: Moments
----
-# My Moments
-
-## 2026-01-28
-
-Today's moment.
-`
-	writeFile(t, filepath.Join(contentDir, "moments.md"), feedPageContent)
-
-	// Create blog post
-	blogDir := filepath.Join(contentDir, "blog")
-	os.MkdirAll(blogDir, 0755)
-	writeFile(t, filepath.Join(blogDir, "2026-01-27-post.md"), "---\ntitle: Blog Post\n---\nPost content")
+	writeFile(t, filepath.Join(contentDir, "moments.md"),
+		"---\ntitle: Moments\n---\n## *2026-03-01*\n\nOne two three.\n\n## *2026-03-01 18:00*\n\nFour five.\n\n## *2025-12-24*\n\nSix.\n")
 
 	cfg := &model.Config{
-		Title:      "Test Site",
-		BaseURL:    "https://example.com",
-		ContentDir: contentDir,
-		OutputDir:  outputDir,
-		FeedPages:  []string{"/moments/"},
+		Title:        "Test Site",
+		BaseURL:      "https://example.com",
+		ContentDir:   contentDir,
+		OutputDir:    outputDir,
+		ArchivePages: []string{"/moments/"},
 	}
 
-	b := New(cfg)
-	err := b.Build()
+	site, err := New(cfg).ScanContent()
 	if err != nil {
-		t.Fatalf("Build() error = %v", err)
+		t.Fatalf("ScanContent() error = %v", err)
 	}
-
-	// Read feed.xml
-	feedPath := filepath.Join(outputDir, "feed.xml")
-	content, err := os.ReadFile(feedPath)
-	if err != nil {
-		t.Fatalf("failed to read feed.xml: %v", err)
+	page := findPageByPath(site.Pages, "/moments/")
+	if page == nil {
+		t.Fatal("moments page not found")
 	}
-
-	feedStr := string(content)
-
-	// Check blog post is in feed
-	if !strings.Contains(feedStr, "<title>Blog Post</title>") {
-		t.Error("feed.xml missing blog post")
+	want := []model.CalendarDay{
+		{Date: time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC), Words: 1, URL: "/moments/2025/12/#2025-12-24"},
+		{Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Words: 5, URL: "/moments/#2026-03-01"},
 	}
-
-	// Check page date section is in feed
-	if !strings.Contains(feedStr, "Moments - January 28, 2026") {
-		t.Error("feed.xml missing page date section")
+	if len(page.Calendar) != len(want) {
+		t.Fatalf("Calendar = %+v, want %+v", page.Calendar, want)
+	}
+	for i := range want {
+		if page.Calendar[i] != want[i] {
+			t.Errorf("Calendar[%d] = %+v, want %+v", i, page.Calendar[i], want[i])
+		}
 	}
 
-	// Check link format for page section
-	if !strings.Contains(feedStr, "https://example.com/moments/#2026-01-28") {
-		t.Error("feed.xml missing page date section link with anchor")
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	for _, path := range []string{"moments/index.html", "moments/2025/12/index.html"} {
+		html, err := os.ReadFile(filepath.Join(outputDir, path))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if !strings.Contains(string(html), `<a href="/moments/2025/12/#2025-12-24">`) {
+			t.Errorf("%s heatmap should link to archived sections", path)
+		}
 	}
 }
 
@@ -2117,3 +3314,257 @@ func TestScanContent_TopicsNotPopulatedWithoutConfig(t *testing.T) {
 	}
 	t.Fatal("moments page not found")
 }
+
+func TestBuild_WritesTopicPages(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	writeFile(t, filepath.Join(contentDir, "moments.md"),
+		"---\ntitle: Moments\n---\nNotes on docker and kubernetes.\n\n"+
+			"## *2026-03-02*\n\nMoved the blog to Docker.\n\n"+
+			"## *2026-03-01*\n\nKubernetes was overkill.\n\n"+
+			"## *2025-12-24*\n\nFirst docker attempt with kubernetes.\n")
+
+	cfg := &model.Config{
+		Title:         "Test Site",
+		BaseURL:       "https://example.com",
+		ContentDir:    contentDir,
+		OutputDir:     outputDir,
+		TopicPages:    []string{"moments"},
+		TopicSettings: map[string]model.TopicSettings{"moments": {Limit: 1}},
+		ArchivePages:  []string{"/moments/"},
+	}
+
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(outputDir, "moments", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read page: %v", err)
+	}
+	if !strings.Contains(string(page), `<a href="/moments/topics/docker/">docker</a>`) {
+		t.Error("page should link its topic to the topic page")
+	}
+	if strings.Contains(string(page), "/moments/topics/kubernetes/") {
+		t.Error("topic limit of 1 should drop kubernetes")
+	}
+
+	html, err := os.ReadFile(filepath.Join(outputDir, "moments", "topics", "docker", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read topic page: %v", err)
+	}
+	for _, want := range []string{
+		"Moved the blog to <mark>Docker</mark>.",
+		"First <mark>docker</mark> attempt with kubernetes.",
+		`<a href="/moments/2025/12/#2025-12-24">`,
+	} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("topic page missing %q", want)
+		}
+	}
+	if strings.Contains(string(html), "Kubernetes was overkill.") {
+		t.Error("topic page should only list sections mentioning the topic")
+	}
+}
+
+func TestScanContent_TopicsFromPageText(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeHomeMd(t, dir)
+	writeFile(t, filepath.Join(dir, "notes.md"),
+		"---\ntitle: Notes\n---\nDocker and more docker.\n\n"+
+			"```\nkubectl apply\nkubectl get\nkubectl logs\n```\n")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: dir,
+		TopicPages: []string{"/notes/"},
+	}
+
+	site, err := New(cfg).ScanContent()
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+	page := findPageByPath(site.Pages, "/notes/")
+	if page == nil {
+		t.Fatal("notes page not found")
+	}
+	if len(page.Topics) != 1 || page.Topics[0].Word != "docker" {
+		t.Errorf("Topics = %v, want only docker, not words from code blocks", page.Topics)
+	}
+}
+
+func TestBuild_WritesTopicIndex(t *testing.T) {
+	t.Parallel()
+
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+	writeHomeMd(t, contentDir)
+
+	writeFile(t, filepath.Join(contentDir, "moments.md"),
+		"---\ntitle: Moments\n---\n"+
+			"## *2026-03-02*\n\nMoved the blog to Docker.\n\n"+
+			"## *2026-03-01*\n\nKubernetes was overkill, docker is enough.\n")
+	if err := os.MkdirAll(filepath.Join(contentDir, "blog"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(contentDir, "blog", "docker.md"),
+		"---\ntitle: Docker Notes\ndate: 2026-03-03\n---\nDocker images and docker volumes.\n")
+
+	cfg := &model.Config{
+		Title:      "Test Site",
+		BaseURL:    "https://example.com",
+		ContentDir: contentDir,
+		OutputDir:  outputDir,
+		TopicPages: []string{"/moments/"},
+		TopicPosts: &model.TopicSettings{},
+	}
+
+	b := New(cfg)
+	site, err := b.ScanContent()
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+	if len(site.Posts) != 1 || len(site.Posts[0].Topics) == 0 || site.Posts[0].Topics[0].Word != "docker" {
+		t.Fatalf("post Topics = %v, want docker first", site.Posts)
+	}
+	if path := site.Posts[0].Topics[0].Path; path != "/topics/#docker" {
+		t.Errorf("post topic Path = %q, want /topics/#docker", path)
+	}
+
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	html, err := os.ReadFile(filepath.Join(outputDir, "topics", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read topic index: %v", err)
+	}
+	for _, want := range []string{
+		`href="#docker"`,
+		`<section class="topic-entry" id="docker">`,
+		`<a href="/blog/docker/">Docker Notes</a>`,
+		`<a href="/moments/#2026-03-02">`,
+		`<a href="/moments/#2026-03-01">`,
+	} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("topic index missing %q", want)
+		}
+	}
+
+	post, err := os.ReadFile(filepath.Join(outputDir, "blog", "docker", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if !strings.Contains(string(post), `<a href="/topics/#docker">docker</a>`) {
+		t.Error("post should link its topics to the topic index")
+	}
+
+	// A topics page in the content takes precedence over the index
+	writeFile(t, filepath.Join(contentDir, "topics.md"), "---\ntitle: My Topics\n---\nHand-written.\n")
+	if err := New(cfg).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	html, err = os.ReadFile(filepath.Join(outputDir, "topics", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read topics page: %v", err)
+	}
+	if !strings.Contains(string(html), "Hand-written.") || strings.Contains(string(html), "topic-cloud") {
+		t.Error("content topics page should not be replaced by the topic index")
+	}
+	post, err = os.ReadFile(filepath.Join(outputDir, "blog", "docker", "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if strings.Contains(string(post), `href="/topics/#`) || !strings.Contains(string(post), `<div class="topics">docker`) {
+		t.Error("post topics should not link to a topic index that is not written")
+	}
+}
+
+func TestScanContent_TopicLanguageAndStopWords(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeHomeMd(t, dir)
+	writeFile(t, filepath.Join(dir, "momenten.md"),
+		"---\ntitle: Momenten\nlang: nl\n---\n"+
+			"het voor een test tests testen het voor een fiets fiets vandaag vandaag\n")
+	stopWordsFile := filepath.Join(t.TempDir(), "stopwords.txt")
+	writeFile(t, stopWordsFile, "# site words\nvandaag\n")
+
+	cfg := &model.Config{
+		Title:          "Test Site",
+		BaseURL:        "https://example.com",
+		ContentDir:     dir,
+		TopicPages:     []string{"/momenten/"},
+		TopicSettings:  map[string]model.TopicSettings{"/momenten/": {Stem: true}},
+		TopicStopWords: stopWordsFile,
+	}
+
+	site, err := New(cfg).ScanContent()
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+	page := findPageByPath(site.Pages, "/momenten/")
+	if page == nil {
+		t.Fatal("momenten page not found")
+	}
+	var got []string
+	for _, topic := range page.Topics {
+		got = append(got, fmt.Sprintf("%s:%d", topic.Word, topic.Count))
+	}
+	if strings.Join(got, ",") != "test:3,fiets:2" {
+		t.Errorf("Topics = %v, want [test:3 fiets:2]", got)
+	}
+
+	cfg.TopicStopWords = filepath.Join(dir, "missing.txt")
+	if _, err := New(cfg).ScanContent(); err == nil || !strings.Contains(err.Error(), "reading topic stop words") {
+		t.Errorf("ScanContent() error = %v, want stop words read error", err)
+	}
+}
+
+func TestScanContent_TopicsTFIDF(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeHomeMd(t, dir)
+	writeFile(t, filepath.Join(dir, "moments.md"),
+		"---\ntitle: Moments\n---\n"+
+			"Exploratory testing today. Exploratory testing again today. Coffee today.\n")
+	writeFile(t, filepath.Join(dir, "now.md"), "---\ntitle: Now\n---\nBusy today.\n")
+	if err := os.MkdirAll(filepath.Join(dir, "blog"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "blog", "2026-01-02-today.md"), "---\ntitle: Today\n---\nAbout today.\n")
+
+	cfg := &model.Config{
+		Title:         "Test Site",
+		BaseURL:       "https://example.com",
+		ContentDir:    dir,
+		TopicPages:    []string{"/moments/"},
+		TopicSettings: map[string]model.TopicSettings{"/moments/": {Mode: "tfidf"}},
+	}
+
+	site, err := New(cfg).ScanContent()
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+	page := findPageByPath(site.Pages, "/moments/")
+	if page == nil {
+		t.Fatal("moments page not found")
+	}
+	if len(page.Topics) != 2 {
+		t.Fatalf("Topics = %+v, want the phrase and today", page.Topics)
+	}
+	if page.Topics[0].Word != "exploratory testing" || page.Topics[0].Path != "/moments/topics/exploratory-testing/" {
+		t.Errorf("Topics[0] = %+v, want the phrase ranked above the site-wide word", page.Topics[0])
+	}
+	if page.Topics[1].Word != "today" {
+		t.Errorf("Topics[1] = %+v, want today", page.Topics[1])
+	}
+}
@@ -3,13 +3,19 @@
 // This package coordinates the complete build pipeline:
 //
 //  1. Scan content directory for markdown files
-//  2. Parse pages and blog posts with frontmatter
+//  2. Parse pages and blog posts with frontmatter, expanding includes and
+//     resolving wiki links
 //  3. Render HTML using templates
 //  4. Write output with clean URLs
 //  5. Copy static assets
-//  6. Generate RSS feed at /feed.xml
-//  7. Generate sitemap.xml with all pages and posts
-//  8. Generate robots.txt with sitemap reference
+//  6. Generate feeds: RSS at /feed.xml, Atom at /atom.xml, JSON Feed at /feed.json,
+//     plus an RSS index.xml for the blog, each tag and each feed page
+//  7. Generate sitemap.xml with all pages and posts and their images, except
+//     noindex ones and those matching sitemap.exclude, split into a sitemap
+//     index beyond 50,000 URLs
+//  8. Generate robots.txt with the robots rules and sitemap reference
+//  9. Generate llms.txt, linking the markdown copies of pages and posts,
+//     and llms-full.txt with llms.full
 //
 // # Clean URLs
 //
@@ -17,6 +23,22 @@
 //
 //	content/about.md     → public/about/index.html
 //	content/blog/post.md → public/blog/post/index.html
+//	tags: [Go]           → public/tags/go/index.xml
+//
+// Next to the index.html of each page and post, index.md holds its markdown
+// under normalised YAML frontmatter. Drafts and noindex pages get no
+// markdown copy and stay out of llms.txt.
+// Pages listed in archive.pages keep their most recent month; older months
+// are written to monthly archive pages such as public/moments/2025/03/index.html,
+// and left out of the page's markdown copy.
+// Pages listed in onThisDay.pages get public/moments/on-this-day/index.html
+// with the sections from earlier years on the build day, next to an
+// index.json of all their date sections. Pages listed in topics.pages get a
+// page per topic, such as public/moments/topics/docker/index.html, listing
+// the date sections that mention it. With topics.posts, blog posts get
+// topics as well, and public/topics/index.html lists all topics with the
+// posts and date sections mentioning them, unless the content has its own
+// topics page. Post topics link to the index only when it is written.
 //
 // # Usage
 //
@@ -27,5 +49,6 @@
 //	}
 //
 // Use [Builder.ScanContent] to scan without building, or [Builder.Build]
-// for the complete build pipeline.
+// for the complete build pipeline. [Builder.Warnings] returns the problems
+// of the last scan that did not fail it, such as math kept as text.
 package builder
@@ -0,0 +1,165 @@
+package builder
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jeroendee/ssg/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// markdownFrontmatter is the normalised frontmatter of markdown copies,
+// written as YAML whatever the format of the source.
+type markdownFrontmatter struct {
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description,omitempty"`
+	Date        string   `yaml:"date,omitempty"`
+	Author      string   `yaml:"author,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Lang        string   `yaml:"lang,omitempty"`
+	URL         string   `yaml:"url"`
+}
+
+// pageFrontmatter returns the normalised frontmatter of page.
+func pageFrontmatter(site model.Site, page model.Page) markdownFrontmatter {
+	return markdownFrontmatter{
+		Title:       cmp.Or(page.Title, site.Title),
+		Description: page.Description,
+		Lang:        page.Lang,
+		URL:         site.BaseURL + page.Path,
+	}
+}
+
+// postFrontmatter returns the normalised frontmatter of post, describing it
+// by its summary when it has no description.
+func postFrontmatter(site model.Site, post model.Post) markdownFrontmatter {
+	fm := pageFrontmatter(site, post.Page)
+	fm.Description = cmp.Or(post.Description, post.Summary)
+	fm.Date = post.Date.Format("2006-01-02")
+	fm.Author = cmp.Or(post.Author, site.Author)
+	fm.Tags = post.Tags
+	return fm
+}
+
+// postMarkdown returns the markdown of post with the paths of its
+// co-located assets rewritten like its HTML.
+func postMarkdown(post model.Post) string {
+	return strings.ReplaceAll(post.Markdown, "](assets/", "](")
+}
+
+// markdownCopy returns a markdown document of body under frontmatter fm.
+func markdownCopy(fm markdownFrontmatter, body string) (string, error) {
+	data, err := yaml.Marshal(fm)
+	if err != nil {
+		return "", err
+	}
+	return "---\n" + string(data) + "---\n\n" + strings.TrimSpace(body) + "\n", nil
+}
+
+// writeMarkdownCopy writes the markdown copy of a page to sitePath, next to
+// its index.html. Pages without a markdown path, such as noindex pages, get
+// no copy.
+func (b *Builder) writeMarkdownCopy(sitePath string, fm markdownFrontmatter, body string) error {
+	if sitePath == "" {
+		return nil
+	}
+	content, err := markdownCopy(fm, body)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.cfg.OutputDir, filepath.FromSlash(sitePath)), []byte(content), 0644)
+}
+
+// writeLLMsTxt creates llms.txt, an index of the pages and posts with
+// markdown copies linking to those copies with their descriptions and
+// summaries. With llms.full it also creates llms-full.txt, holding the
+// markdown copies themselves.
+func (b *Builder) writeLLMsTxt(site model.Site) error {
+	var index, full strings.Builder
+	header := "# " + site.Title + "\n"
+	if b.cfg.Description != "" {
+		header += "\n> " + oneLine(b.cfg.Description) + "\n"
+	}
+	index.WriteString(header)
+	full.WriteString(header)
+
+	var pages, posts []string
+	for _, page := range site.Pages {
+		if page.MarkdownPath == "" {
+			continue
+		}
+		page, _ = splitArchivePage(page)
+		fm := pageFrontmatter(site, page)
+		pages = append(pages, llmsLink(fm, site.BaseURL+page.MarkdownPath))
+		body, err := b.markdownWikiLinks(site, page.Path, page.Markdown)
+		if err != nil {
+			return err
+		}
+		if err := writeFullEntry(&full, fm, body); err != nil {
+			return err
+		}
+	}
+	for _, post := range site.Posts {
+		if post.MarkdownPath == "" {
+			continue
+		}
+		fm := postFrontmatter(site, post)
+		posts = append(posts, llmsLink(fm, site.BaseURL+post.MarkdownPath))
+		body, err := b.markdownWikiLinks(site, post.Path, postMarkdown(post))
+		if err != nil {
+			return err
+		}
+		if err := writeFullEntry(&full, fm, body); err != nil {
+			return err
+		}
+	}
+	writeLLMsSection(&index, "Pages", pages)
+	writeLLMsSection(&index, "Blog", posts)
+
+	if err := os.WriteFile(filepath.Join(b.cfg.OutputDir, "llms.txt"), []byte(index.String()), 0644); err != nil {
+		return err
+	}
+	if !b.cfg.LLMsFull {
+		return nil
+	}
+	return os.WriteFile(filepath.Join(b.cfg.OutputDir, "llms-full.txt"), []byte(full.String()), 0644)
+}
+
+// writeLLMsSection writes a section of llms.txt listing links, if any.
+func writeLLMsSection(buf *strings.Builder, heading string, links []string) {
+	if len(links) == 0 {
+		return
+	}
+	buf.WriteString("\n## " + heading + "\n\n")
+	for _, link := range links {
+		buf.WriteString(link + "\n")
+	}
+}
+
+// writeFullEntry appends the markdown copy of a page to llms-full.txt.
+func writeFullEntry(buf *strings.Builder, fm markdownFrontmatter, body string) error {
+	content, err := markdownCopy(fm, body)
+	if err != nil {
+		return err
+	}
+	buf.WriteString("\n" + content)
+	return nil
+}
+
+// llmsLink returns the llms.txt list item linking to a markdown copy at
+// url, followed by its description when set.
+func llmsLink(fm markdownFrontmatter, url string) string {
+	title := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(oneLine(fm.Title))
+	link := "- [" + title + "](" + url + ")"
+	if fm.Description != "" {
+		link += ": " + oneLine(fm.Description)
+	}
+	return link
+}
+
+// oneLine collapses the whitespace of s, including newlines, to single spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
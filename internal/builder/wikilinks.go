@@ -0,0 +1,132 @@
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jeroendee/ssg/internal/model"
+	"github.com/jeroendee/ssg/internal/parser"
+)
+
+// linkTarget is a page or post that wiki links can point to.
+type linkTarget struct {
+	Title string
+	Slug  string
+	URL   string
+}
+
+// matches reports whether a wiki link target refers to this page or post.
+// Targets match by slug, by path (e.g. "blog/slug"), or by title ignoring case.
+func (t linkTarget) matches(target string) bool {
+	path := strings.Trim(t.URL, "/")
+	return target == t.Slug && t.Slug != "" ||
+		strings.Trim(target, "/") == path && path != "" ||
+		strings.EqualFold(target, t.Title)
+}
+
+// wikiLinkResolver returns a function resolving the wiki link targets of the
+// page at source to the URLs of the site's pages and posts.
+// It returns an error for targets that match no page or more than one.
+func wikiLinkResolver(site model.Site) func(source, target string) (string, error) {
+	var targets []linkTarget
+	for _, page := range site.Pages {
+		targets = append(targets, linkTarget{Title: page.Title, Slug: page.Slug, URL: page.Path})
+	}
+	for _, post := range site.Posts {
+		targets = append(targets, linkTarget{Title: post.Title, Slug: post.Slug, URL: post.Path})
+	}
+
+	return func(source, target string) (string, error) {
+		var found []linkTarget
+		for _, t := range targets {
+			if t.matches(target) {
+				found = append(found, t)
+			}
+		}
+		switch len(found) {
+		case 0:
+			return "", fmt.Errorf("%s: unresolved wiki link [[%s]]", source, target)
+		case 1:
+			return found[0].URL, nil
+		default:
+			return "", fmt.Errorf("%s: ambiguous wiki link [[%s]] matches %s and %s", source, target, found[0].URL, found[1].URL)
+		}
+	}
+}
+
+// resolveWikiLinks replaces wiki link placeholders in all pages, posts and post
+// excerpts with URLs and records backlinks on each link target.
+// Returns an error for targets that match no page or more than one.
+func resolveWikiLinks(site *model.Site) error {
+	resolve := wikiLinkResolver(*site)
+
+	backlinks := make(map[string][]model.Backlink)
+	link := func(page *model.Page) error {
+		seen := make(map[string]bool)
+		content, err := parser.ResolveWikiLinks(page.Content, func(target string) (string, error) {
+			url, err := resolve(page.Path, target)
+			if err != nil {
+				return "", err
+			}
+			if url != page.Path && !seen[url] {
+				seen[url] = true
+				backlinks[url] = append(backlinks[url], model.Backlink{Title: page.Title, URL: page.Path})
+			}
+			return url, nil
+		})
+		if err != nil {
+			return err
+		}
+		page.Content = content
+		return nil
+	}
+
+	for i := range site.Pages {
+		if err := link(&site.Pages[i]); err != nil {
+			return err
+		}
+	}
+	for i := range site.Posts {
+		post := &site.Posts[i]
+		if err := link(&post.Page); err != nil {
+			return err
+		}
+		// The excerpt is part of the content, so its links are already
+		// recorded as backlinks
+		excerpt, err := parser.ResolveWikiLinks(post.Excerpt, func(target string) (string, error) {
+			return resolve(post.Path, target)
+		})
+		if err != nil {
+			return err
+		}
+		post.Excerpt = excerpt
+	}
+
+	for url, links := range backlinks {
+		sort.Slice(links, func(i, j int) bool {
+			return links[i].Title < links[j].Title
+		})
+		backlinks[url] = links
+	}
+	for i := range site.Pages {
+		site.Pages[i].Backlinks = backlinks[site.Pages[i].Path]
+	}
+	for i := range site.Posts {
+		site.Posts[i].Backlinks = backlinks[site.Posts[i].Path]
+	}
+
+	return nil
+}
+
+// markdownWikiLinks returns markdown, the source of the page at source, with
+// its wiki links rewritten to markdown links when wiki links are enabled.
+func (b *Builder) markdownWikiLinks(site model.Site, source, markdown string) (string, error) {
+	if !b.cfg.WikiLinks {
+		return markdown, nil
+	}
+	resolve := wikiLinkResolver(site)
+	return parser.ResolveMarkdownWikiLinks(markdown, func(target string) (string, error) {
+		return resolve(source, target)
+	})
+}
@@ -1,10 +1,15 @@
 package config
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/jeroendee/ssg/internal/model"
+	"github.com/jeroendee/ssg/internal/topics"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,10 +38,116 @@ type yamlConfig struct {
 	} `yaml:"analytics"`
 	Feed struct {
 		Pages []string `yaml:"pages"`
+		Title string   `yaml:"title"`
 	} `yaml:"feed"`
+	Feeds struct {
+		Formats []string `yaml:"formats"`
+		Limit   int      `yaml:"limit"`
+		Content string   `yaml:"content"`
+	} `yaml:"feeds"`
+	Podcast *struct {
+		Author      string `yaml:"author"`
+		Email       string `yaml:"email"`
+		Image       string `yaml:"image"`
+		Category    string `yaml:"category"`
+		Subcategory string `yaml:"subcategory"`
+		Explicit    bool   `yaml:"explicit"`
+		Type        string `yaml:"type"`
+	} `yaml:"podcast"`
 	Topics struct {
-		Pages []string `yaml:"pages"`
+		Pages         []topicPage `yaml:"pages"`
+		Lang          string      `yaml:"lang"`
+		Stem          bool        `yaml:"stem"`
+		StopWordsFile string      `yaml:"stopWordsFile"`
+		Posts         topicPosts  `yaml:"posts"`
 	} `yaml:"topics"`
+	Archive struct {
+		Pages []string `yaml:"pages"`
+	} `yaml:"archive"`
+	OnThisDay struct {
+		Pages []string `yaml:"pages"`
+	} `yaml:"onThisDay"`
+	Markdown struct {
+		Figures   bool `yaml:"figures"`
+		WikiLinks bool `yaml:"wikiLinks"`
+		Math      bool `yaml:"math"`
+	} `yaml:"markdown"`
+	ReadingTime struct {
+		WordsPerMinute int `yaml:"wordsPerMinute"`
+	} `yaml:"readingTime"`
+	Robots struct {
+		BlockAI bool `yaml:"blockAI"`
+		Rules   []struct {
+			UserAgent string   `yaml:"userAgent"`
+			Allow     []string `yaml:"allow"`
+			Disallow  []string `yaml:"disallow"`
+		} `yaml:"rules"`
+	} `yaml:"robots"`
+	Sitemap struct {
+		Exclude []string `yaml:"exclude"`
+	} `yaml:"sitemap"`
+	LLMs struct {
+		Full bool `yaml:"full"`
+	} `yaml:"llms"`
+}
+
+// topicPage is an entry of topics.pages: a page path, or a mapping with the
+// path and its extraction settings.
+type topicPage struct {
+	Path      string `yaml:"path"`
+	Limit     int    `yaml:"limit"`
+	MinLength int    `yaml:"minLength"`
+	MinCount  int    `yaml:"minCount"`
+	Lang      string `yaml:"lang"`
+	Mode      string `yaml:"mode"`
+}
+
+// UnmarshalYAML accepts a plain path or a mapping of settings.
+func (p *topicPage) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&p.Path)
+	}
+	type plain topicPage
+	return node.Decode((*plain)(p))
+}
+
+// settings validates the extraction settings of p and converts them; where
+// locates p in error messages.
+func (p topicPage) settings(where string) (model.TopicSettings, error) {
+	if p.Limit < 0 || p.MinLength < 0 || p.MinCount < 0 {
+		return model.TopicSettings{}, fmt.Errorf("config: topic settings %s must not be negative", where)
+	}
+	if p.Lang != "" {
+		if _, ok := topics.Language(p.Lang); !ok {
+			return model.TopicSettings{}, fmt.Errorf("config: unsupported language %q %s (use %s)", p.Lang, where, strings.Join(topics.Languages(), ", "))
+		}
+	}
+	if p.Mode != "" && p.Mode != topics.ModeFrequency && p.Mode != topics.ModeTFIDF {
+		return model.TopicSettings{}, fmt.Errorf("config: unknown topic mode %q %s (use frequency or tfidf)", p.Mode, where)
+	}
+	return model.TopicSettings{
+		Limit:     p.Limit,
+		MinLength: p.MinLength,
+		MinCount:  p.MinCount,
+		Lang:      p.Lang,
+		Mode:      p.Mode,
+	}, nil
+}
+
+// topicPosts is topics.posts: true to extract topics for blog posts, or a
+// mapping of their extraction settings.
+type topicPosts struct {
+	Enabled  bool
+	Settings topicPage
+}
+
+// UnmarshalYAML accepts a boolean or a mapping of settings.
+func (p *topicPosts) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&p.Enabled)
+	}
+	p.Enabled = true
+	return node.Decode(&p.Settings)
 }
 
 // Options provides CLI flag overrides for configuration.
@@ -76,10 +187,97 @@ func LoadWithOptions(path string, opts Options) (*model.Config, error) {
 		feedPages = []string{}
 	}
 
-	// Initialize TopicPages to empty slice if nil
-	topicPages := yc.Topics.Pages
-	if topicPages == nil {
-		topicPages = []string{}
+	if yc.Feed.Title != "" {
+		if _, err := model.ParseFeedTitle(yc.Feed.Title); err != nil {
+			return nil, fmt.Errorf("config: invalid 'feed.title' template: %w", err)
+		}
+	}
+
+	// Default to RSS and reject empty or unknown feed formats
+	feedFormats := yc.Feeds.Formats
+	if feedFormats == nil {
+		feedFormats = []string{model.FeedRSS}
+	}
+	if len(feedFormats) == 0 {
+		return nil, errors.New("config: 'feeds.formats' must list at least one format (rss, atom or json)")
+	}
+	for _, format := range feedFormats {
+		if _, ok := model.FeedLinkFor(format); !ok {
+			return nil, fmt.Errorf("config: unknown feed format %q in 'feeds.formats' (use rss, atom or json)", format)
+		}
+	}
+
+	if yc.Feeds.Limit < 0 {
+		return nil, errors.New("config: 'feeds.limit' must not be negative")
+	}
+	if yc.Feeds.Content != "" && yc.Feeds.Content != "full" && yc.Feeds.Content != "summary" && yc.Feeds.Content != "excerpt" {
+		return nil, fmt.Errorf("config: unknown feed content %q in 'feeds.content' (use full, summary or excerpt)", yc.Feeds.Content)
+	}
+
+	if yc.ReadingTime.WordsPerMinute < 0 {
+		return nil, errors.New("config: 'readingTime.wordsPerMinute' must not be negative")
+	}
+
+	// Validate robots.txt rules
+	var robots []model.RobotsGroup
+	for _, rule := range yc.Robots.Rules {
+		if rule.UserAgent == "" {
+			return nil, errors.New("config: missing 'userAgent' in 'robots.rules' entry")
+		}
+		if len(rule.Allow) == 0 && len(rule.Disallow) == 0 {
+			return nil, fmt.Errorf("config: robots rule for %q needs 'allow' or 'disallow' paths", rule.UserAgent)
+		}
+		for _, p := range slices.Concat(rule.Allow, rule.Disallow) {
+			if !strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "*") {
+				return nil, fmt.Errorf("config: robots path %q for %q must start with / or *", p, rule.UserAgent)
+			}
+		}
+		robots = append(robots, model.RobotsGroup{
+			UserAgent: rule.UserAgent,
+			Allow:     rule.Allow,
+			Disallow:  rule.Disallow,
+		})
+	}
+
+	for _, pattern := range yc.Sitemap.Exclude {
+		if err := model.ValidatePathPattern(pattern); err != nil {
+			return nil, fmt.Errorf("config: invalid glob %q in 'sitemap.exclude': %w", pattern, err)
+		}
+	}
+
+	if yc.Topics.Lang != "" {
+		if _, ok := topics.Language(yc.Topics.Lang); !ok {
+			return nil, fmt.Errorf("config: unsupported language %q in 'topics.lang' (use %s)", yc.Topics.Lang, strings.Join(topics.Languages(), ", "))
+		}
+	}
+
+	// Collect topic pages with their extraction settings
+	topicPages := []string{}
+	topicSettings := make(map[string]model.TopicSettings)
+	for _, page := range yc.Topics.Pages {
+		if page.Path == "" {
+			return nil, errors.New("config: missing 'path' in 'topics.pages' entry")
+		}
+		settings, err := page.settings(fmt.Sprintf("for %q in 'topics.pages'", page.Path))
+		if err != nil {
+			return nil, err
+		}
+		settings.Lang = cmp.Or(settings.Lang, yc.Topics.Lang)
+		settings.Stem = yc.Topics.Stem
+		topicPages = append(topicPages, page.Path)
+		topicSettings[page.Path] = settings
+	}
+
+	// Topic extraction for blog posts
+	var topicPosts *model.TopicSettings
+	if yc.Topics.Posts.Enabled {
+		settings, err := yc.Topics.Posts.Settings.settings("in 'topics.posts'")
+		if err != nil {
+			return nil, err
+		}
+		settings.Lang = cmp.Or(settings.Lang, yc.Topics.Lang)
+		settings.Stem = yc.Topics.Stem
+		topicPosts = &settings
 	}
 
 	cfg := &model.Config{
@@ -96,8 +294,26 @@ func LoadWithOptions(path string, opts Options) (*model.Config, error) {
 		Analytics: model.Analytics{
 			GoatCounter: yc.Analytics.GoatCounter,
 		},
-		FeedPages:  feedPages,
-		TopicPages: topicPages,
+		FeedPages:       feedPages,
+		FeedTitle:       yc.Feed.Title,
+		FeedFormats:     feedFormats,
+		FeedLimit:       yc.Feeds.Limit,
+		FeedSummaryOnly: yc.Feeds.Content == "summary",
+		FeedExcerpts:    yc.Feeds.Content == "excerpt",
+		TopicPages:      topicPages,
+		TopicSettings:   topicSettings,
+		TopicStopWords:  yc.Topics.StopWordsFile,
+		TopicPosts:      topicPosts,
+		ArchivePages:    yc.Archive.Pages,
+		OnThisDayPages:  yc.OnThisDay.Pages,
+		Figures:         yc.Markdown.Figures,
+		WikiLinks:       yc.Markdown.WikiLinks,
+		Math:            yc.Markdown.Math,
+		WordsPerMinute:  yc.ReadingTime.WordsPerMinute,
+		Robots:          robots,
+		RobotsBlockAI:   yc.Robots.BlockAI,
+		SitemapExclude:  yc.Sitemap.Exclude,
+		LLMsFull:        yc.LLMs.Full,
 	}
 
 	// Apply defaults
@@ -122,6 +338,22 @@ func LoadWithOptions(path string, opts Options) (*model.Config, error) {
 		cfg.AssetsDir = opts.AssetsDir
 	}
 
+	// Convert podcast metadata
+	if p := yc.Podcast; p != nil {
+		if p.Type != "" && p.Type != "episodic" && p.Type != "serial" {
+			return nil, fmt.Errorf("config: unknown podcast type %q in 'podcast.type' (use episodic or serial)", p.Type)
+		}
+		cfg.Podcast = &model.Podcast{
+			Author:      p.Author,
+			Email:       p.Email,
+			Image:       p.Image,
+			Category:    p.Category,
+			Subcategory: p.Subcategory,
+			Explicit:    p.Explicit,
+			Type:        p.Type,
+		}
+	}
+
 	// Convert navigation
 	for _, nav := range yc.Navigation {
 		cfg.Navigation = append(cfg.Navigation, model.NavItem{
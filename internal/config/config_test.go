@@ -3,6 +3,8 @@ package config_test
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jeroendee/ssg/internal/config"
@@ -498,6 +500,260 @@ topics:
 	}
 }
 
+func TestLoad_TopicPageSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		topics  string
+		want    map[string]model.TopicSettings
+		wantErr string
+	}{
+		{
+			name:   "plain paths use defaults",
+			topics: "topics:\n  pages:\n    - /moments/\n",
+			want:   map[string]model.TopicSettings{"/moments/": {}},
+		},
+		{
+			name:   "settings per page",
+			topics: "topics:\n  pages:\n    - /moments/\n    - path: /now/\n      limit: 10\n      minLength: 4\n      minCount: 3\n",
+			want: map[string]model.TopicSettings{
+				"/moments/": {},
+				"/now/":     {Limit: 10, MinLength: 4, MinCount: 3},
+			},
+		},
+		{
+			name:    "missing path",
+			topics:  "topics:\n  pages:\n    - limit: 10\n",
+			wantErr: "missing 'path'",
+		},
+		{
+			name:    "negative setting",
+			topics:  "topics:\n  pages:\n    - path: /now/\n      minCount: -1\n",
+			wantErr: "must not be negative",
+		},
+		{
+			name:   "tfidf mode",
+			topics: "topics:\n  pages:\n    - path: /now/\n      mode: tfidf\n",
+			want:   map[string]model.TopicSettings{"/now/": {Mode: "tfidf"}},
+		},
+		{
+			name:    "unknown mode",
+			topics:  "topics:\n  pages:\n    - path: /now/\n      mode: bm25\n",
+			wantErr: "unknown topic mode \"bm25\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.topics
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(cfg.TopicSettings) != len(tt.want) {
+				t.Fatalf("TopicSettings = %v, want %v", cfg.TopicSettings, tt.want)
+			}
+			for path, want := range tt.want {
+				if got, ok := cfg.TopicSettings[path]; !ok || !reflect.DeepEqual(got, want) {
+					t.Errorf("TopicSettings[%q] = %+v, want %+v", path, got, want)
+				}
+			}
+			if len(cfg.TopicPages) != len(tt.want) {
+				t.Errorf("TopicPages = %v, want %d pages", cfg.TopicPages, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestLoad_TopicPosts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		topics  string
+		want    *model.TopicSettings
+		wantErr string
+	}{
+		{
+			name:   "omitted",
+			topics: "",
+			want:   nil,
+		},
+		{
+			name:   "disabled",
+			topics: "topics:\n  posts: false\n",
+			want:   nil,
+		},
+		{
+			name:   "enabled with defaults",
+			topics: "topics:\n  lang: nl\n  stem: true\n  posts: true\n",
+			want:   &model.TopicSettings{Lang: "nl", Stem: true},
+		},
+		{
+			name:   "settings",
+			topics: "topics:\n  posts:\n    limit: 5\n    mode: tfidf\n",
+			want:   &model.TopicSettings{Limit: 5, Mode: "tfidf"},
+		},
+		{
+			name:    "unknown mode",
+			topics:  "topics:\n  posts:\n    mode: bm25\n",
+			wantErr: "unknown topic mode \"bm25\" in 'topics.posts'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.topics
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.TopicPosts, tt.want) {
+				t.Errorf("TopicPosts = %+v, want %+v", cfg.TopicPosts, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_TopicLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		topics        string
+		want          model.TopicSettings
+		wantStopWords string
+		wantErr       string
+	}{
+		{
+			name:   "global language and stemming",
+			topics: "topics:\n  lang: nl\n  stem: true\n  stopWordsFile: stopwords.txt\n  pages:\n    - /moments/\n",
+			want:   model.TopicSettings{Lang: "nl", Stem: true},
+
+			wantStopWords: "stopwords.txt",
+		},
+		{
+			name:   "page language overrides global",
+			topics: "topics:\n  lang: nl\n  pages:\n    - path: /moments/\n      lang: en-GB\n",
+			want:   model.TopicSettings{Lang: "en-GB"},
+		},
+		{
+			name:    "unsupported global language",
+			topics:  "topics:\n  lang: xx\n  pages:\n    - /moments/\n",
+			wantErr: "unsupported language \"xx\" in 'topics.lang'",
+		},
+		{
+			name:    "unsupported page language",
+			topics:  "topics:\n  pages:\n    - path: /moments/\n      lang: klingon\n",
+			wantErr: "unsupported language \"klingon\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.topics
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.TopicSettings["/moments/"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopicSettings = %+v, want %+v", got, tt.want)
+			}
+			if cfg.TopicStopWords != tt.wantStopWords {
+				t.Errorf("TopicStopWords = %q, want %q", cfg.TopicStopWords, tt.wantStopWords)
+			}
+		})
+	}
+}
+
+func TestLoad_ArchivePages(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "ssg.yaml")
+	content := `site:
+  title: "Test Site"
+  baseURL: "https://example.com"
+archive:
+  pages:
+    - /moments/
+`
+	if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.ArchivePages) != 1 || cfg.ArchivePages[0] != "/moments/" {
+		t.Errorf("ArchivePages = %v, want [/moments/]", cfg.ArchivePages)
+	}
+}
+
+func TestLoad_OnThisDayPages(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "ssg.yaml")
+	content := `site:
+  title: "Test Site"
+  baseURL: "https://example.com"
+onThisDay:
+  pages:
+    - /moments/
+`
+	if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.OnThisDayPages) != 1 || cfg.OnThisDayPages[0] != "/moments/" {
+		t.Errorf("OnThisDayPages = %v, want [/moments/]", cfg.OnThisDayPages)
+	}
+}
+
 func TestLoad_TopicPagesOmitted(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -587,3 +843,464 @@ func TestLoad_WithoutOGImage(t *testing.T) {
 		t.Errorf("OGImage = %q, want empty string", cfg.OGImage)
 	}
 }
+
+func TestLoad_MarkdownFigures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name: "enabled",
+			content: `site:
+  title: "Test Site"
+  baseURL: "https://example.com"
+markdown:
+  figures: true
+`,
+			want: true,
+		},
+		{
+			name: "omitted defaults to false",
+			content: `site:
+  title: "Test Site"
+  baseURL: "https://example.com"
+`,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			if err := os.WriteFile(cfgFile, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Figures != tt.want {
+				t.Errorf("Figures = %v, want %v", cfg.Figures, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_MarkdownWikiLinks(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "ssg.yaml")
+	content := `site:
+  title: "Test Site"
+  baseURL: "https://example.com"
+markdown:
+  wikiLinks: true
+`
+	if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.WikiLinks {
+		t.Error("WikiLinks = false, want true")
+	}
+	if cfg.Figures {
+		t.Error("Figures = true, want false when omitted")
+	}
+}
+
+func TestLoad_MarkdownMath(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "ssg.yaml")
+	content := `site:
+  title: "Test Site"
+  baseURL: "https://example.com"
+markdown:
+  math: true
+`
+	if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.Math {
+		t.Error("Math = false, want true")
+	}
+	if cfg.WikiLinks {
+		t.Error("WikiLinks = true, want false when omitted")
+	}
+}
+
+func TestLoad_FeedFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		feeds   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "defaults to rss",
+			feeds: "",
+			want:  []string{"rss"},
+		},
+		{
+			name:  "selects formats",
+			feeds: "feeds:\n  formats: [rss, atom, json]\n",
+			want:  []string{"rss", "atom", "json"},
+		},
+		{
+			name:  "defaults to rss without formats",
+			feeds: "feeds:\n  formats:\n",
+			want:  []string{"rss"},
+		},
+		{
+			name:    "rejects empty formats",
+			feeds:   "feeds:\n  formats: []\n",
+			wantErr: "'feeds.formats' must list at least one format",
+		},
+		{
+			name:    "rejects unknown format",
+			feeds:   "feeds:\n  formats: [atom, rdf]\n",
+			wantErr: `unknown feed format "rdf"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.feeds
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if strings.Join(cfg.FeedFormats, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FeedFormats = %v, want %v", cfg.FeedFormats, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_FeedLimitAndContent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		feeds           string
+		wantLimit       int
+		wantSummaryOnly bool
+		wantExcerpts    bool
+		wantErr         string
+	}{
+		{
+			name:  "defaults",
+			feeds: "",
+		},
+		{
+			name:            "limit and summary content",
+			feeds:           "feeds:\n  limit: 50\n  content: summary\n",
+			wantLimit:       50,
+			wantSummaryOnly: true,
+		},
+		{
+			name:  "full content",
+			feeds: "feeds:\n  content: full\n",
+		},
+		{
+			name:         "excerpt content",
+			feeds:        "feeds:\n  content: excerpt\n",
+			wantExcerpts: true,
+		},
+		{
+			name:    "negative limit",
+			feeds:   "feeds:\n  limit: -1\n",
+			wantErr: "'feeds.limit' must not be negative",
+		},
+		{
+			name:    "unknown content",
+			feeds:   "feeds:\n  content: teaser\n",
+			wantErr: `unknown feed content "teaser"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.feeds
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.FeedLimit != tt.wantLimit {
+				t.Errorf("FeedLimit = %d, want %d", cfg.FeedLimit, tt.wantLimit)
+			}
+			if cfg.FeedSummaryOnly != tt.wantSummaryOnly {
+				t.Errorf("FeedSummaryOnly = %v, want %v", cfg.FeedSummaryOnly, tt.wantSummaryOnly)
+			}
+			if cfg.FeedExcerpts != tt.wantExcerpts {
+				t.Errorf("FeedExcerpts = %v, want %v", cfg.FeedExcerpts, tt.wantExcerpts)
+			}
+		})
+	}
+}
+
+func TestLoad_ReadingTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		yaml    string
+		want    int
+		wantErr string
+	}{
+		{name: "default", yaml: "", want: 0},
+		{name: "words per minute", yaml: "readingTime:\n  wordsPerMinute: 250\n", want: 250},
+		{name: "negative", yaml: "readingTime:\n  wordsPerMinute: -1\n", wantErr: "'readingTime.wordsPerMinute' must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.yaml
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.WordsPerMinute != tt.want {
+				t.Errorf("WordsPerMinute = %d, want %d", cfg.WordsPerMinute, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_CrawlerFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		yaml        string
+		wantRobots  []model.RobotsGroup
+		wantBlockAI bool
+		wantExclude []string
+		wantLLMs    bool
+		wantErr     string
+	}{
+		{name: "default", yaml: ""},
+		{name: "llms-full.txt", yaml: "llms:\n  full: true\n", wantLLMs: true},
+		{
+			name: "rules and exclusions",
+			yaml: "robots:\n  blockAI: true\n  rules:\n    - userAgent: \"*\"\n      disallow: [/drafts/]\n    - userAgent: Googlebot\n      allow: [/]\n" +
+				"sitemap:\n  exclude: [\"/tags/**\"]\n",
+			wantRobots: []model.RobotsGroup{
+				{UserAgent: "*", Disallow: []string{"/drafts/"}},
+				{UserAgent: "Googlebot", Allow: []string{"/"}},
+			},
+			wantBlockAI: true,
+			wantExclude: []string{"/tags/**"},
+		},
+		{name: "missing user agent", yaml: "robots:\n  rules:\n    - disallow: [/]\n", wantErr: "missing 'userAgent'"},
+		{name: "no paths", yaml: "robots:\n  rules:\n    - userAgent: GPTBot\n", wantErr: "needs 'allow' or 'disallow'"},
+		{name: "relative path", yaml: "robots:\n  rules:\n    - userAgent: GPTBot\n      disallow: [private/]\n", wantErr: "must start with / or *"},
+		{name: "bad glob", yaml: "sitemap:\n  exclude: [\"/tags/[a-\"]\n", wantErr: "invalid glob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.yaml
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.Robots, tt.wantRobots) {
+				t.Errorf("Robots = %+v, want %+v", cfg.Robots, tt.wantRobots)
+			}
+			if cfg.RobotsBlockAI != tt.wantBlockAI {
+				t.Errorf("RobotsBlockAI = %v, want %v", cfg.RobotsBlockAI, tt.wantBlockAI)
+			}
+			if !reflect.DeepEqual(cfg.SitemapExclude, tt.wantExclude) {
+				t.Errorf("SitemapExclude = %q, want %q", cfg.SitemapExclude, tt.wantExclude)
+			}
+			if cfg.LLMsFull != tt.wantLLMs {
+				t.Errorf("LLMsFull = %v, want %v", cfg.LLMsFull, tt.wantLLMs)
+			}
+		})
+	}
+}
+
+func TestLoad_Podcast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		podcast string
+		want    *model.Podcast
+		wantErr string
+	}{
+		{
+			name: "omitted",
+		},
+		{
+			name:    "full metadata",
+			podcast: "podcast:\n  author: Jane Doe\n  email: jane@example.com\n  image: /podcast.jpg\n  category: Technology\n  subcategory: Tech News\n  explicit: true\n  type: serial\n",
+			want: &model.Podcast{
+				Author:      "Jane Doe",
+				Email:       "jane@example.com",
+				Image:       "/podcast.jpg",
+				Category:    "Technology",
+				Subcategory: "Tech News",
+				Explicit:    true,
+				Type:        "serial",
+			},
+		},
+		{
+			name:    "unknown type",
+			podcast: "podcast:\n  type: weekly\n",
+			wantErr: `unknown podcast type "weekly"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.podcast
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if (cfg.Podcast == nil) != (tt.want == nil) || (cfg.Podcast != nil && *cfg.Podcast != *tt.want) {
+				t.Errorf("Podcast = %+v, want %+v", cfg.Podcast, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_FeedTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		feed    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "omitted",
+		},
+		{
+			name: "template",
+			feed: "feed:\n  title: \"{{.Page}}: {{.Title}}\"\n",
+			want: "{{.Page}}: {{.Title}}",
+		},
+		{
+			name:    "syntax error",
+			feed:    "feed:\n  title: \"{{.Title\"\n",
+			wantErr: "invalid 'feed.title' template",
+		},
+		{
+			name:    "unknown field",
+			feed:    "feed:\n  title: \"{{.Heading}}\"\n",
+			wantErr: "invalid 'feed.title' template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cfgFile := filepath.Join(dir, "ssg.yaml")
+			content := "site:\n  title: \"Test Site\"\n  baseURL: \"https://example.com\"\n" + tt.feed
+			if err := os.WriteFile(cfgFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(cfgFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.FeedTitle != tt.want {
+				t.Errorf("FeedTitle = %q, want %q", cfg.FeedTitle, tt.want)
+			}
+		})
+	}
+}
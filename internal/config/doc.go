@@ -17,10 +17,67 @@
 //	navigation:
 //	  - title: Home
 //	    url: /
+//	feed:
+//	  pages: [/now/]
+//	  title: "{{.Page}}: {{.Title}}"
+//	archive:
+//	  pages: [/moments/]
+//	onThisDay:
+//	  pages: [/moments/]
+//	topics:
+//	  lang: nl
+//	  stem: true
+//	  stopWordsFile: stopwords.txt
+//	  pages:
+//	    - /moments/
+//	    - path: /now/
+//	      limit: 10
+//	      minLength: 4
+//	      minCount: 3
+//	      mode: tfidf
+//	  posts:
+//	    limit: 5
+//	feeds:
+//	  formats: [rss, atom, json]
+//	  limit: 20
+//	  content: summary
+//	podcast:
+//	  email: jane@example.com
+//	  image: /podcast.jpg
+//	  category: Technology
+//	  type: episodic
+//	markdown:
+//	  figures: true
+//	  wikiLinks: true
+//	  math: true
+//	readingTime:
+//	  wordsPerMinute: 250
+//	robots:
+//	  blockAI: true
+//	  rules:
+//	    - userAgent: "*"
+//	      disallow: [/drafts/]
+//	sitemap:
+//	  exclude: ["/moments/topics/**"]
+//	llms:
+//	  full: true
 //
 // Use [Load] to read configuration from a file, or [LoadWithOptions] to
 // apply CLI flag overrides for assets, content, and output directories.
 //
 // Required fields are site.title and site.baseURL. Default values are
 // applied for assets ("assets"), content ("content"), and output ("public") directories.
+// feeds.formats defaults to rss; unknown formats are rejected. feeds.limit
+// defaults to 20 items and feeds.content to full; it may also be summary or
+// excerpt. podcast.type must be
+// episodic or serial when set. feed.title must be a valid template using
+// the fields of [model.FeedTitleData]. topics.pages entries are a path or
+// a mapping with a path and non-negative [model.TopicSettings]. topics.lang
+// and the lang of topics.pages entries must be languages supported by
+// [topics.Language], and mode must be frequency or tfidf. topics.posts is
+// a boolean or a mapping of the same settings without a path.
+// readingTime.wordsPerMinute defaults to 200 and must not be negative.
+// robots.rules entries need a userAgent and allow or disallow paths starting
+// with / or *. sitemap.exclude entries must be valid globs for
+// [model.MatchPath].
 package config
@@ -0,0 +1,10 @@
+// Package mathml converts LaTeX math expressions to MathML markup.
+//
+// [Convert] supports a practical subset of LaTeX: fractions, roots,
+// subscripts and superscripts, Greek letters, sums, products, integrals and
+// limits, accents, font alphabets such as \mathbb, \left...\right fences,
+// and matrix environments (matrix, pmatrix, bmatrix, vmatrix, cases).
+// Unknown commands return an error rather than rendering incorrectly.
+//
+// The output renders natively in browsers without JavaScript.
+package mathml
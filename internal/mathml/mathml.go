@@ -0,0 +1,642 @@
+package mathml
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Convert translates a LaTeX math expression into a MathML <math> element.
+// Display mode produces a block-level equation. The LaTeX source is kept
+// as an annotation so it survives copy and paste.
+// Lines separated by \\ are stacked as rows of a table.
+func Convert(tex string, display bool) (string, error) {
+	tex = strings.TrimSpace(tex)
+	p := &texParser{tokens: tokenize(tex)}
+
+	var lines []string
+	for {
+		row, err := p.parseRow(nil)
+		if err != nil {
+			return "", fmt.Errorf(`math "%s": %w`, tex, err)
+		}
+		lines = append(lines, mrow(row))
+		t, ok := p.next()
+		if !ok {
+			break
+		}
+		if t.kind != tokNewRow {
+			return "", fmt.Errorf(`math "%s": unexpected %s`, tex, t)
+		}
+	}
+	body := lines[0]
+	if len(lines) > 1 {
+		body = "<mtable>"
+		for _, line := range lines {
+			body += "<mtr><mtd>" + line + "</mtd></mtr>"
+		}
+		body += "</mtable>"
+	}
+
+	var b strings.Builder
+	b.WriteString("<math")
+	if display {
+		b.WriteString(` display="block"`)
+	}
+	b.WriteString("><semantics>")
+	b.WriteString(body)
+	b.WriteString(`<annotation encoding="application/x-tex">`)
+	b.WriteString(html.EscapeString(tex))
+	b.WriteString("</annotation></semantics></math>")
+	return b.String(), nil
+}
+
+// tokenKind classifies LaTeX tokens.
+type tokenKind int
+
+const (
+	tokLetter tokenKind = iota
+	tokNumber
+	tokSymbol
+	tokCommand
+	tokOpen
+	tokClose
+	tokSup
+	tokSub
+	tokAlign
+	tokNewRow
+	tokPrime
+)
+
+// token is a single LaTeX token. Space records whether whitespace preceded it.
+type token struct {
+	kind  tokenKind
+	value string
+	space bool
+}
+
+// String returns the token as it appears in LaTeX source, for error messages.
+func (t token) String() string {
+	switch t.kind {
+	case tokCommand:
+		return `"\` + t.value + `"`
+	case tokNewRow:
+		return `"\\"`
+	default:
+		return `"` + t.value + `"`
+	}
+}
+
+// tokenize splits LaTeX source into tokens.
+func tokenize(tex string) []token {
+	var tokens []token
+	runes := []rune(tex)
+	space := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		t := token{space: space}
+		space = false
+		switch {
+		case r == '\\':
+			if i+1 >= len(runes) {
+				t.kind, t.value = tokSymbol, `\`
+				break
+			}
+			if runes[i+1] == '\\' {
+				t.kind, t.value = tokNewRow, `\\`
+				i++
+				break
+			}
+			j := i + 1
+			for j < len(runes) && unicode.IsLetter(runes[j]) {
+				j++
+			}
+			if j == i+1 {
+				j++ // single non-letter command such as \, or \{
+			}
+			t.kind, t.value = tokCommand, string(runes[i+1:j])
+			i = j - 1
+		case unicode.IsDigit(r) || r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.' && j+1 < len(runes) && unicode.IsDigit(runes[j+1])) {
+				j++
+			}
+			t.kind, t.value = tokNumber, string(runes[i:j])
+			i = j - 1
+		case unicode.IsLetter(r):
+			t.kind, t.value = tokLetter, string(r)
+		case r == '{':
+			t.kind, t.value = tokOpen, "{"
+		case r == '}':
+			t.kind, t.value = tokClose, "}"
+		case r == '^':
+			t.kind, t.value = tokSup, "^"
+		case r == '_':
+			t.kind, t.value = tokSub, "_"
+		case r == '&':
+			t.kind, t.value = tokAlign, "&"
+		case r == '\'':
+			t.kind, t.value = tokPrime, "'"
+		default:
+			t.kind, t.value = tokSymbol, string(r)
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// texParser converts a token stream into MathML markup.
+type texParser struct {
+	tokens  []token
+	pos     int
+	variant string // active math alphabet, e.g. "bold" inside \mathbf
+}
+
+// peek returns the next token without consuming it.
+func (p *texParser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// next consumes and returns the next token.
+func (p *texParser) next() (token, bool) {
+	t, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return t, ok
+}
+
+// isRowEnd reports whether t ends the current row.
+func isRowEnd(t token) bool {
+	switch t.kind {
+	case tokClose, tokAlign, tokNewRow:
+		return true
+	case tokCommand:
+		return t.value == "end" || t.value == "right"
+	}
+	return false
+}
+
+// parseRow parses items until a row end or until stop returns true.
+func (p *texParser) parseRow(stop func(token) bool) ([]string, error) {
+	var items []string
+	for {
+		t, ok := p.peek()
+		if !ok || isRowEnd(t) || stop != nil && stop(t) {
+			return items, nil
+		}
+		item, err := p.parseScripted()
+		if err != nil {
+			return nil, err
+		}
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+}
+
+// parseScripted parses an atom followed by any subscript, superscript or primes.
+func (p *texParser) parseScripted() (string, error) {
+	base, limits, err := p.parseAtom()
+	if err != nil {
+		return "", err
+	}
+
+	var sub, sup, primes string
+	var hasSub, hasSup bool
+	for {
+		t, ok := p.peek()
+		if !ok {
+			break
+		}
+		if t.kind == tokCommand && (t.value == "limits" || t.value == "nolimits") {
+			p.next()
+			limits = t.value == "limits"
+			continue
+		}
+		if t.kind == tokPrime {
+			p.next()
+			primes += "′"
+			continue
+		}
+		if t.kind != tokSub && t.kind != tokSup {
+			break
+		}
+		p.next()
+		arg, err := p.parseArg()
+		if err != nil {
+			return "", err
+		}
+		if t.kind == tokSub {
+			if hasSub {
+				return "", errors.New("double subscript")
+			}
+			sub, hasSub = arg, true
+		} else {
+			if hasSup {
+				return "", errors.New("double superscript")
+			}
+			sup, hasSup = arg, true
+		}
+	}
+	if primes != "" {
+		items := []string{mo(primes)}
+		if hasSup {
+			items = append(items, sup)
+		}
+		sup, hasSup = mrow(items), true
+	}
+
+	switch {
+	case hasSub && hasSup && limits:
+		return "<munderover>" + base + sub + sup + "</munderover>", nil
+	case hasSub && hasSup:
+		return "<msubsup>" + base + sub + sup + "</msubsup>", nil
+	case hasSub && limits:
+		return "<munder>" + base + sub + "</munder>", nil
+	case hasSub:
+		return "<msub>" + base + sub + "</msub>", nil
+	case hasSup && limits:
+		return "<mover>" + base + sup + "</mover>", nil
+	case hasSup:
+		return "<msup>" + base + sup + "</msup>", nil
+	}
+	return base, nil
+}
+
+// parseArg parses a single command or script argument: a group or one atom.
+func (p *texParser) parseArg() (string, error) {
+	t, ok := p.peek()
+	if !ok {
+		return "", errors.New("missing argument")
+	}
+	if isRowEnd(t) || t.kind == tokSub || t.kind == tokSup {
+		return "", fmt.Errorf("missing argument before %s", t)
+	}
+	if t.kind == tokNumber && len(t.value) > 1 {
+		// A bare argument is a single character: \frac12 is \frac{1}{2}
+		p.tokens[p.pos].value = t.value[1:]
+		p.tokens[p.pos].space = false
+		return "<mn>" + p.styled(t.value[:1]) + "</mn>", nil
+	}
+	atom, _, err := p.parseAtom()
+	return atom, err
+}
+
+// parseGroup parses a braced group and returns its items.
+func (p *texParser) parseGroup() ([]string, error) {
+	if t, ok := p.next(); !ok || t.kind != tokOpen {
+		return nil, errors.New("expected {")
+	}
+	row, err := p.parseRow(nil)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.next(); !ok || t.kind != tokClose {
+		return nil, errors.New("missing closing brace")
+	}
+	return row, nil
+}
+
+// parseText reads a braced group as literal text, preserving spaces.
+// Leading and trailing spaces become non-breaking so MathML keeps them.
+func (p *texParser) parseText() (string, error) {
+	if t, ok := p.next(); !ok || t.kind != tokOpen {
+		return "", errors.New("expected {")
+	}
+	var b strings.Builder
+	depth := 0
+	for {
+		t, ok := p.next()
+		if !ok {
+			return "", errors.New("missing closing brace")
+		}
+		if t.space {
+			b.WriteByte(' ')
+		}
+		if t.kind == tokClose && depth == 0 {
+			text := b.String()
+			trimmed := strings.TrimLeft(text, " ")
+			text = strings.Repeat("\u00a0", len(text)-len(trimmed)) + trimmed
+			trimmed = strings.TrimRight(text, " ")
+			return trimmed + strings.Repeat("\u00a0", len(text)-len(trimmed)), nil
+		}
+		switch t.kind {
+		case tokOpen:
+			depth++
+		case tokClose:
+			depth--
+		case tokCommand:
+			if len(t.value) == 1 && !unicode.IsLetter(rune(t.value[0])) {
+				b.WriteString(t.value)
+			} else {
+				b.WriteString(`\` + t.value)
+			}
+		default:
+			b.WriteString(t.value)
+		}
+	}
+}
+
+// parseAtom parses one element. limits reports whether scripts attached to it
+// go above and below (as for \sum) rather than to the side.
+func (p *texParser) parseAtom() (atom string, limits bool, err error) {
+	t, ok := p.peek()
+	if !ok {
+		return "", false, errors.New("unexpected end of input")
+	}
+	switch t.kind {
+	case tokSub, tokSup, tokPrime:
+		return "<mrow></mrow>", false, nil
+	case tokOpen:
+		row, err := p.parseGroup()
+		if err != nil {
+			return "", false, err
+		}
+		return mrow(row), false, nil
+	}
+
+	p.next()
+	switch t.kind {
+	case tokLetter:
+		return p.identifier(t.value), false, nil
+	case tokNumber:
+		return "<mn>" + p.styled(t.value) + "</mn>", false, nil
+	case tokSymbol:
+		if op, ok := symbolOperators[t.value]; ok {
+			return mo(op), false, nil
+		}
+		return mo(t.value), false, nil
+	case tokCommand:
+		return p.parseCommand(t.value)
+	}
+	return "", false, fmt.Errorf("unexpected %s", t)
+}
+
+// parseCommand converts a LaTeX command and its arguments.
+func (p *texParser) parseCommand(name string) (string, bool, error) {
+	if s, ok := greekLetters[name]; ok {
+		if unicode.IsUpper([]rune(s)[0]) {
+			return `<mi mathvariant="normal">` + s + "</mi>", false, nil
+		}
+		return "<mi>" + s + "</mi>", false, nil
+	}
+	if s, ok := identifiers[name]; ok {
+		return "<mi>" + s + "</mi>", false, nil
+	}
+	if s, ok := operators[name]; ok {
+		return mo(s), false, nil
+	}
+	if s, ok := largeOperators[name]; ok {
+		return mo(s), !strings.HasPrefix(name, "i") && !strings.HasPrefix(name, "oi"), nil
+	}
+	if functions[name] {
+		return "<mi>" + name + "</mi>", limitFunctions[name], nil
+	}
+	if width, ok := spaces[name]; ok {
+		return `<mspace width="` + width + `"></mspace>`, false, nil
+	}
+	if accent, ok := accents[name]; ok {
+		arg, err := p.parseArg()
+		if err != nil {
+			return "", false, err
+		}
+		if name == "underline" {
+			return `<munder accentunder="true">` + arg + mo(accent) + "</munder>", false, nil
+		}
+		return `<mover accent="true">` + arg + mo(accent) + "</mover>", false, nil
+	}
+	if variant, ok := alphabets[name]; ok {
+		outer := p.variant
+		p.variant = variant
+		arg, err := p.parseArg()
+		p.variant = outer
+		return arg, false, err
+	}
+
+	switch name {
+	case "frac", "dfrac", "tfrac", "binom":
+		num, err := p.parseArg()
+		if err != nil {
+			return "", false, err
+		}
+		den, err := p.parseArg()
+		if err != nil {
+			return "", false, err
+		}
+		if name == "binom" {
+			return mrow([]string{mo("("), `<mfrac linethickness="0">` + num + den + "</mfrac>", mo(")")}), false, nil
+		}
+		return "<mfrac>" + num + den + "</mfrac>", false, nil
+	case "sqrt":
+		if t, ok := p.peek(); ok && t.kind == tokSymbol && t.value == "[" {
+			p.next()
+			index, err := p.parseRow(func(t token) bool { return t.kind == tokSymbol && t.value == "]" })
+			if err != nil {
+				return "", false, err
+			}
+			if t, ok := p.next(); !ok || t.value != "]" {
+				return "", false, errors.New("missing ] in \\sqrt")
+			}
+			arg, err := p.parseArg()
+			if err != nil {
+				return "", false, err
+			}
+			return "<mroot>" + arg + mrow(index) + "</mroot>", false, nil
+		}
+		arg, err := p.parseArg()
+		if err != nil {
+			return "", false, err
+		}
+		return "<msqrt>" + arg + "</msqrt>", false, nil
+	case "text", "textrm", "mbox":
+		text, err := p.parseText()
+		if err != nil {
+			return "", false, err
+		}
+		return "<mtext>" + html.EscapeString(text) + "</mtext>", false, nil
+	case "mathrm", "operatorname":
+		text, err := p.parseText()
+		if err != nil {
+			return "", false, err
+		}
+		return `<mi mathvariant="normal">` + html.EscapeString(text) + "</mi>", false, nil
+	case "left":
+		return p.parseFenced()
+	case "big", "Big", "bigg", "Bigg", "bigl", "bigr", "Bigl", "Bigr", "biggl", "biggr", "Biggl", "Biggr":
+		delim, err := p.parseDelimiter()
+		if err != nil {
+			return "", false, err
+		}
+		return mo(delim), false, nil
+	case "begin":
+		return p.parseEnvironment()
+	case "displaystyle", "textstyle":
+		return "", false, nil
+	}
+	return "", false, fmt.Errorf(`unknown command "\%s"`, name)
+}
+
+// parseDelimiter reads the delimiter following \left, \right or \big.
+// A "." delimiter is invisible and returns an empty string.
+func (p *texParser) parseDelimiter() (string, error) {
+	t, ok := p.next()
+	if !ok {
+		return "", errors.New("missing delimiter")
+	}
+	switch t.kind {
+	case tokSymbol:
+		if t.value == "." {
+			return "", nil
+		}
+		return t.value, nil
+	case tokCommand:
+		if d, ok := delimiters[t.value]; ok {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("invalid delimiter %s", t)
+}
+
+// parseFenced parses \left<delim> ... \right<delim>.
+func (p *texParser) parseFenced() (string, bool, error) {
+	open, err := p.parseDelimiter()
+	if err != nil {
+		return "", false, err
+	}
+	row, err := p.parseRow(nil)
+	if err != nil {
+		return "", false, err
+	}
+	if t, ok := p.next(); !ok || t.kind != tokCommand || t.value != "right" {
+		return "", false, errors.New(`missing "\right"`)
+	}
+	closing, err := p.parseDelimiter()
+	if err != nil {
+		return "", false, err
+	}
+	return fenced(open, closing, row), false, nil
+}
+
+// parseEnvironment parses \begin{name} ... \end{name} into a table.
+func (p *texParser) parseEnvironment() (string, bool, error) {
+	name, err := p.parseText()
+	if err != nil {
+		return "", false, err
+	}
+	env, ok := environments[name]
+	if !ok {
+		return "", false, fmt.Errorf("unknown environment %q", name)
+	}
+
+	var rows [][]string
+	var cells []string
+	for {
+		cell, err := p.parseRow(nil)
+		if err != nil {
+			return "", false, err
+		}
+		cells = append(cells, mrow(cell))
+
+		t, ok := p.next()
+		if !ok {
+			return "", false, fmt.Errorf(`missing "\end{%s}"`, name)
+		}
+		switch {
+		case t.kind == tokAlign:
+			continue
+		case t.kind == tokNewRow:
+			rows = append(rows, cells)
+			cells = nil
+			continue
+		case t.kind == tokCommand && t.value == "end":
+			end, err := p.parseText()
+			if err != nil {
+				return "", false, err
+			}
+			if end != name {
+				return "", false, fmt.Errorf(`"\end{%s}" does not match "\begin{%s}"`, end, name)
+			}
+			if len(cells) > 1 || cells[0] != "<mrow></mrow>" {
+				rows = append(rows, cells)
+			}
+		default:
+			return "", false, fmt.Errorf("unexpected %s in %s", t, name)
+		}
+		break
+	}
+
+	var b strings.Builder
+	b.WriteString("<mtable")
+	if env.align != "" {
+		b.WriteString(` columnalign="` + env.align + `"`)
+	}
+	b.WriteString(">")
+	for _, row := range rows {
+		b.WriteString("<mtr>")
+		for _, cell := range row {
+			b.WriteString("<mtd>" + cell + "</mtd>")
+		}
+		b.WriteString("</mtr>")
+	}
+	b.WriteString("</mtable>")
+
+	if env.open == "" && env.close == "" {
+		return b.String(), false, nil
+	}
+	return fenced(env.open, env.close, []string{b.String()}), false, nil
+}
+
+// identifier returns a single-letter identifier in the active alphabet.
+func (p *texParser) identifier(letter string) string {
+	return "<mi>" + p.styled(letter) + "</mi>"
+}
+
+// styled maps letters and digits to the active math alphabet.
+func (p *texParser) styled(s string) string {
+	if p.variant == "" {
+		return html.EscapeString(s)
+	}
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteRune(mathAlphabet(p.variant, r))
+	}
+	return html.EscapeString(b.String())
+}
+
+// mo returns an operator element.
+func mo(s string) string {
+	return "<mo>" + html.EscapeString(s) + "</mo>"
+}
+
+// mrow wraps items in an mrow unless there is exactly one.
+func mrow(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return "<mrow>" + strings.Join(items, "") + "</mrow>"
+}
+
+// fenced wraps items in stretchy opening and closing delimiters.
+func fenced(open, closing string, items []string) string {
+	var b strings.Builder
+	b.WriteString("<mrow>")
+	if open != "" {
+		b.WriteString(`<mo fence="true">` + html.EscapeString(open) + "</mo>")
+	}
+	b.WriteString(strings.Join(items, ""))
+	if closing != "" {
+		b.WriteString(`<mo fence="true">` + html.EscapeString(closing) + "</mo>")
+	}
+	b.WriteString("</mrow>")
+	return b.String()
+}
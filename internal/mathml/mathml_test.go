@@ -0,0 +1,178 @@
+package mathml_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jeroendee/ssg/internal/mathml"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tex  string
+		want string
+	}{
+		{
+			name: "superscript and subscript",
+			tex:  "x^2 + y_1",
+			want: "<mrow><msup><mi>x</mi><mn>2</mn></msup><mo>+</mo><msub><mi>y</mi><mn>1</mn></msub></mrow>",
+		},
+		{
+			name: "combined scripts",
+			tex:  "x_i^{2}",
+			want: "<msubsup><mi>x</mi><mi>i</mi><mn>2</mn></msubsup>",
+		},
+		{
+			name: "fraction",
+			tex:  `\frac{a+b}{2}`,
+			want: "<mfrac><mrow><mi>a</mi><mo>+</mo><mi>b</mi></mrow><mn>2</mn></mfrac>",
+		},
+		{
+			name: "fraction with bare digits",
+			tex:  `\frac12`,
+			want: "<mfrac><mn>1</mn><mn>2</mn></mfrac>",
+		},
+		{
+			name: "greek letters",
+			tex:  `\alpha \Omega`,
+			want: `<mrow><mi>α</mi><mi mathvariant="normal">Ω</mi></mrow>`,
+		},
+		{
+			name: "sum with limits",
+			tex:  `\sum_{i=1}^{n} i`,
+			want: "<mrow><munderover><mo>∑</mo><mrow><mi>i</mi><mo>=</mo><mn>1</mn></mrow><mi>n</mi></munderover><mi>i</mi></mrow>",
+		},
+		{
+			name: "integral keeps side scripts",
+			tex:  `\int_0^\infty f`,
+			want: "<mrow><msubsup><mo>∫</mo><mn>0</mn><mi>∞</mi></msubsup><mi>f</mi></mrow>",
+		},
+		{
+			name: "limit",
+			tex:  `\lim_{x \to 0}`,
+			want: "<munder><mi>lim</mi><mrow><mi>x</mi><mo>→</mo><mn>0</mn></mrow></munder>",
+		},
+		{
+			name: "square and nth roots",
+			tex:  `\sqrt{x} \sqrt[3]{y}`,
+			want: "<mrow><msqrt><mi>x</mi></msqrt><mroot><mi>y</mi><mn>3</mn></mroot></mrow>",
+		},
+		{
+			name: "pmatrix",
+			tex:  `\begin{pmatrix} a & b \\ c & d \end{pmatrix}`,
+			want: `<mrow><mo fence="true">(</mo><mtable><mtr><mtd><mi>a</mi></mtd><mtd><mi>b</mi></mtd></mtr><mtr><mtd><mi>c</mi></mtd><mtd><mi>d</mi></mtd></mtr></mtable><mo fence="true">)</mo></mrow>`,
+		},
+		{
+			name: "cases",
+			tex:  `\begin{cases} 1 & x > 0 \\ 0 & \text{otherwise} \end{cases}`,
+			want: `<mrow><mo fence="true">{</mo><mtable columnalign="left left"><mtr><mtd><mn>1</mn></mtd><mtd><mrow><mi>x</mi><mo>&gt;</mo><mn>0</mn></mrow></mtd></mtr><mtr><mtd><mn>0</mn></mtd><mtd><mtext>otherwise</mtext></mtd></mtr></mtable></mrow>`,
+		},
+		{
+			name: "left right fences",
+			tex:  `\left[ x \right)`,
+			want: `<mrow><mo fence="true">[</mo><mi>x</mi><mo fence="true">)</mo></mrow>`,
+		},
+		{
+			name: "blackboard bold",
+			tex:  `\mathbb{R}^n`,
+			want: "<msup><mi>ℝ</mi><mi>n</mi></msup>",
+		},
+		{
+			name: "accent",
+			tex:  `\hat{n}`,
+			want: `<mover accent="true"><mi>n</mi><mo>^</mo></mover>`,
+		},
+		{
+			name: "prime",
+			tex:  `f'(x)`,
+			want: "<mrow><msup><mi>f</mi><mo>′</mo></msup><mo>(</mo><mi>x</mi><mo>)</mo></mrow>",
+		},
+		{
+			name: "text keeps trailing space",
+			tex:  `\text{if } x`,
+			want: "<mrow><mtext>if </mtext><mi>x</mi></mrow>",
+		},
+		{
+			name: "minus sign and decimal",
+			tex:  `-3.14`,
+			want: "<mrow><mo>−</mo><mn>3.14</mn></mrow>",
+		},
+		{
+			name: "rows",
+			tex:  `a \\ b`,
+			want: "<mtable><mtr><mtd><mi>a</mi></mtd></mtr><mtr><mtd><mi>b</mi></mtd></mtr></mtable>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := mathml.Convert(tt.tex, false)
+			if err != nil {
+				t.Fatalf("Convert(%q) error = %v", tt.tex, err)
+			}
+			want := "<math><semantics>" + tt.want + `<annotation encoding="application/x-tex">`
+			if !strings.HasPrefix(got, want) {
+				t.Errorf("Convert(%q) = %q, want prefix %q", tt.tex, got, want)
+			}
+		})
+	}
+}
+
+func TestConvert_DisplayMode(t *testing.T) {
+	t.Parallel()
+	got, err := mathml.Convert("x", true)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := `<math display="block"><semantics><mi>x</mi><annotation encoding="application/x-tex">x</annotation></semantics></math>`
+	if got != want {
+		t.Errorf("Convert() = %q, want %q", got, want)
+	}
+}
+
+func TestConvert_EscapesAnnotation(t *testing.T) {
+	t.Parallel()
+	got, err := mathml.Convert("a < b", false)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if !strings.Contains(got, `<annotation encoding="application/x-tex">a &lt; b</annotation>`) {
+		t.Errorf("Convert() = %q, want escaped annotation", got)
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tex     string
+		wantErr string
+	}{
+		{name: "unknown command", tex: `\foo`, wantErr: `unknown command "\foo"`},
+		{name: "unclosed brace", tex: `{x`, wantErr: "missing closing brace"},
+		{name: "stray closing brace", tex: `x}`, wantErr: `unexpected "}"`},
+		{name: "missing fraction argument", tex: `\frac{a}`, wantErr: "missing argument"},
+		{name: "double superscript", tex: `x^a^b`, wantErr: "double superscript"},
+		{name: "unknown environment", tex: `\begin{foo}x\end{foo}`, wantErr: `unknown environment "foo"`},
+		{name: "mismatched environment", tex: `\begin{pmatrix}x\end{bmatrix}`, wantErr: "does not match"},
+		{name: "missing right", tex: `\left( x`, wantErr: `missing "\right"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := mathml.Convert(tt.tex, false)
+			if err == nil {
+				t.Fatalf("Convert(%q) expected error", tt.tex)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Convert(%q) error = %q, want to contain %q", tt.tex, err, tt.wantErr)
+			}
+		})
+	}
+}
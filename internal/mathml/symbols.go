@@ -0,0 +1,159 @@
+package mathml
+
+// greekLetters maps Greek letter commands to their characters.
+var greekLetters = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ",
+	"varepsilon": "ε", "zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ",
+	"iota": "ι", "kappa": "κ", "lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ",
+	"omicron": "ο", "pi": "π", "varpi": "ϖ", "rho": "ρ", "varrho": "ϱ",
+	"sigma": "σ", "varsigma": "ς", "tau": "τ", "upsilon": "υ", "phi": "ϕ",
+	"varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ",
+	"Pi": "Π", "Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+}
+
+// identifiers maps symbol commands that behave like variables.
+var identifiers = map[string]string{
+	"infty": "∞", "partial": "∂", "nabla": "∇", "emptyset": "∅", "varnothing": "∅",
+	"ell": "ℓ", "hbar": "ℏ", "aleph": "ℵ", "Re": "ℜ", "Im": "ℑ",
+	"$": "$", "%": "%", "#": "#", "_": "_",
+}
+
+// operators maps operator, relation and arrow commands to their characters.
+var operators = map[string]string{
+	"+": "+", "cdot": "⋅", "times": "×", "div": "÷", "pm": "±", "mp": "∓",
+	"ast": "∗", "star": "⋆", "circ": "∘", "bullet": "∙", "oplus": "⊕", "otimes": "⊗",
+	"cup": "∪", "cap": "∩", "setminus": "∖", "wedge": "∧", "land": "∧", "vee": "∨", "lor": "∨",
+	"neg": "¬", "lnot": "¬",
+	"leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠", "ne": "≠",
+	"approx": "≈", "equiv": "≡", "sim": "∼", "simeq": "≃", "cong": "≅", "propto": "∝",
+	"ll": "≪", "gg": "≫", "in": "∈", "notin": "∉", "ni": "∋",
+	"subset": "⊂", "subseteq": "⊆", "supset": "⊃", "supseteq": "⊇",
+	"forall": "∀", "exists": "∃", "mid": "∣", "parallel": "∥", "perp": "⊥",
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "gets": "←", "leftrightarrow": "↔",
+	"Rightarrow": "⇒", "Leftarrow": "⇐", "Leftrightarrow": "⇔", "iff": "⟺",
+	"implies": "⟹", "mapsto": "↦", "uparrow": "↑", "downarrow": "↓",
+	"ldots": "…", "cdots": "⋯", "vdots": "⋮", "ddots": "⋱", "dots": "…",
+	"{": "{", "}": "}", "lbrace": "{", "rbrace": "}", "|": "‖", "vert": "|",
+	"langle": "⟨", "rangle": "⟩", "lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉",
+	"&": "&", "colon": ":", "prime": "′", "angle": "∠", "triangle": "△", "therefore": "∴",
+}
+
+// symbolOperators maps ASCII symbols to their typographic operator characters.
+var symbolOperators = map[string]string{
+	"-": "−",
+	"*": "∗",
+}
+
+// largeOperators maps big operator commands. Integrals keep side scripts;
+// the others take limits above and below.
+var largeOperators = map[string]string{
+	"sum": "∑", "prod": "∏", "coprod": "∐", "bigcup": "⋃", "bigcap": "⋂",
+	"bigoplus": "⨁", "bigotimes": "⨂", "bigvee": "⋁", "bigwedge": "⋀",
+	"int": "∫", "iint": "∬", "iiint": "∭", "oint": "∮",
+}
+
+// functions lists named functions rendered upright.
+var functions = map[string]bool{
+	"sin": true, "cos": true, "tan": true, "cot": true, "sec": true, "csc": true,
+	"arcsin": true, "arccos": true, "arctan": true, "sinh": true, "cosh": true, "tanh": true,
+	"log": true, "ln": true, "lg": true, "exp": true, "det": true, "dim": true, "ker": true,
+	"deg": true, "gcd": true, "arg": true, "Pr": true,
+	"lim": true, "liminf": true, "limsup": true, "max": true, "min": true, "sup": true, "inf": true,
+}
+
+// limitFunctions lists functions whose scripts go below, as in \lim_{x \to 0}.
+var limitFunctions = map[string]bool{
+	"lim": true, "liminf": true, "limsup": true, "max": true, "min": true,
+	"sup": true, "inf": true, "det": true, "gcd": true, "Pr": true,
+}
+
+// spaces maps spacing commands to widths.
+var spaces = map[string]string{
+	",": "0.1667em", "thinspace": "0.1667em", ":": "0.2222em", ">": "0.2222em",
+	";": "0.2778em", " ": "0.25em", "quad": "1em", "qquad": "2em", "!": "-0.1667em",
+}
+
+// accents maps accent commands to the mark placed over (or under) the argument.
+var accents = map[string]string{
+	"hat": "^", "widehat": "^", "bar": "‾", "overline": "‾", "underline": "_",
+	"vec": "→", "dot": "˙", "ddot": "¨", "tilde": "~", "widetilde": "~",
+}
+
+// delimiters maps delimiter commands usable after \left, \right and \big.
+var delimiters = map[string]string{
+	"{": "{", "}": "}", "lbrace": "{", "rbrace": "}", "|": "‖", "vert": "|", "Vert": "‖",
+	"langle": "⟨", "rangle": "⟩", "lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉",
+	"lvert": "|", "rvert": "|", "lVert": "‖", "rVert": "‖",
+}
+
+// environment describes the fences and column alignment of a matrix-like environment.
+type environment struct {
+	open, close string
+	align       string
+}
+
+// environments lists the supported \begin{...} environments.
+var environments = map[string]environment{
+	"matrix":   {},
+	"pmatrix":  {open: "(", close: ")"},
+	"bmatrix":  {open: "[", close: "]"},
+	"Bmatrix":  {open: "{", close: "}"},
+	"vmatrix":  {open: "|", close: "|"},
+	"Vmatrix":  {open: "‖", close: "‖"},
+	"cases":    {open: "{", align: "left left"},
+	"aligned":  {align: "right left"},
+	"align":    {align: "right left"},
+	"align*":   {align: "right left"},
+	"gathered": {},
+}
+
+// alphabets maps font commands to math alphabets.
+var alphabets = map[string]string{
+	"mathbf": "bold", "boldsymbol": "bold", "mathbb": "double-struck",
+	"mathcal": "script", "mathscr": "script", "mathfrak": "fraktur",
+	"mathsf": "sans-serif", "mathtt": "monospace", "mathit": "italic",
+}
+
+// alphabetStarts holds the first code points of A, a and 0 in each math alphabet.
+// A zero start means the alphabet has no such characters.
+var alphabetStarts = map[string][3]rune{
+	"bold":          {0x1D400, 0x1D41A, 0x1D7CE},
+	"double-struck": {0x1D538, 0x1D552, 0x1D7D8},
+	"script":        {0x1D49C, 0x1D4B6, 0},
+	"fraktur":       {0x1D504, 0x1D51E, 0},
+	"sans-serif":    {0x1D5A0, 0x1D5BA, 0x1D7E2},
+	"monospace":     {0x1D670, 0x1D68A, 0x1D7F6},
+	"italic":        {0x1D434, 0x1D44E, 0},
+}
+
+// alphabetExceptions holds letters that live outside the contiguous
+// Mathematical Alphanumeric Symbols block.
+var alphabetExceptions = map[string]map[rune]rune{
+	"double-struck": {'C': 'ℂ', 'H': 'ℍ', 'N': 'ℕ', 'P': 'ℙ', 'Q': 'ℚ', 'R': 'ℝ', 'Z': 'ℤ'},
+	"script": {'B': 'ℬ', 'E': 'ℰ', 'F': 'ℱ', 'H': 'ℋ', 'I': 'ℐ', 'L': 'ℒ', 'M': 'ℳ', 'R': 'ℛ',
+		'e': 'ℯ', 'g': 'ℊ', 'o': 'ℴ'},
+	"fraktur": {'C': 'ℭ', 'H': 'ℌ', 'I': 'ℑ', 'R': 'ℜ', 'Z': 'ℨ'},
+	"italic":  {'h': 'ℎ'},
+}
+
+// mathAlphabet maps an ASCII letter or digit to the given math alphabet.
+// Other characters are returned unchanged.
+func mathAlphabet(variant string, r rune) rune {
+	if mapped, ok := alphabetExceptions[variant][r]; ok {
+		return mapped
+	}
+	starts, ok := alphabetStarts[variant]
+	if !ok {
+		return r
+	}
+	switch {
+	case r >= 'A' && r <= 'Z' && starts[0] != 0:
+		return starts[0] + r - 'A'
+	case r >= 'a' && r <= 'z' && starts[1] != 0:
+		return starts[1] + r - 'a'
+	case r >= '0' && r <= '9' && starts[2] != 0:
+		return starts[2] + r - '0'
+	}
+	return r
+}
@@ -1,9 +1,13 @@
 package model
 
 import (
+	"io"
 	"path"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
+	"unicode"
 )
 
 // NavItem represents a navigation menu entry.
@@ -17,8 +21,57 @@ type FeedItem interface {
 	FeedTitle() string
 	FeedLink() string
 	FeedContent() string
+	FeedSummary() string
+	FeedExcerpt() string
 	FeedDate() time.Time
 	FeedGUID() string
+	FeedAuthor() string
+	FeedCategories() []string
+	FeedMedia() *FeedMedia
+}
+
+// FeedMedia is a media file published as a feed enclosure.
+type FeedMedia struct {
+	URL      string // Absolute URL of the media file
+	Length   int64  // File size in bytes
+	Type     string // MIME type
+	Duration string // Episode duration, e.g. "32:15"
+	Episode  int    // Episode number; zero when unset
+}
+
+// DefaultFeedLimit is the number of feed items published when no limit is configured.
+const DefaultFeedLimit = 20
+
+// Feed formats selectable with feeds.formats.
+const (
+	FeedRSS  = "rss"
+	FeedAtom = "atom"
+	FeedJSON = "json"
+)
+
+// FeedLink describes a feed format and where it is published.
+type FeedLink struct {
+	Format string
+	Type   string // MIME type for <link rel="alternate">
+	Title  string
+	Href   string // Site-relative path of the feed file
+}
+
+// feedLinks lists the supported feed formats in output order.
+var feedLinks = []FeedLink{
+	{Format: FeedRSS, Type: "application/rss+xml", Title: "RSS Feed", Href: "/feed.xml"},
+	{Format: FeedAtom, Type: "application/atom+xml", Title: "Atom Feed", Href: "/atom.xml"},
+	{Format: FeedJSON, Type: "application/feed+json", Title: "JSON Feed", Href: "/feed.json"},
+}
+
+// FeedLinkFor returns the feed link for a format name.
+func FeedLinkFor(format string) (FeedLink, bool) {
+	for _, link := range feedLinks {
+		if link.Format == format {
+			return link, true
+		}
+	}
+	return FeedLink{}, false
 }
 
 // MonthGroup groups date anchors by year and month for archive navigation.
@@ -26,6 +79,47 @@ type MonthGroup struct {
 	Year  int
 	Month string
 	Dates []string
+	Path  string // Archive page of the month; empty when the dates are on the same page
+}
+
+// OnThisDayDir is the subdirectory of a page holding its "on this day" page.
+const OnThisDayDir = "on-this-day/"
+
+// ArchivePath returns the path of the monthly archive page of the page at
+// pagePath for the month of date, e.g. "/moments/2025/03/".
+func ArchivePath(pagePath string, date time.Time) string {
+	return pagePath + date.Format("2006/01/")
+}
+
+// CalendarDay is a day with date sections in a page's calendar heatmap.
+type CalendarDay struct {
+	Date  time.Time // The day at midnight UTC
+	Words int       // Total length of the day's sections in words
+	URL   string    // Site-relative URL of the day's first section
+}
+
+// Calendar groups sections by day for a calendar heatmap, oldest day first.
+// Each day links to its first section in page order.
+func Calendar(sections []DateSection) []CalendarDay {
+	var days []CalendarDay
+	index := make(map[string]int)
+	for _, s := range sections {
+		key := s.Date.Format("2006-01-02")
+		i, ok := index[key]
+		if !ok {
+			i = len(days)
+			index[key] = i
+			days = append(days, CalendarDay{
+				Date: time.Date(s.Date.Year(), s.Date.Month(), s.Date.Day(), 0, 0, 0, 0, time.UTC),
+				URL:  s.URL(),
+			})
+		}
+		days[i].Words += s.Words
+	}
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days
 }
 
 // YearGroup groups months by year for hierarchical archive navigation.
@@ -38,6 +132,89 @@ type YearGroup struct {
 type Topic struct {
 	Word  string
 	Count int
+	Forms []string // Inflections counted under Word by stemming, including Word
+	Path  string   // Page listing what mentions the topic: a page's topic page or the topic index; empty when there is none
+}
+
+// Words returns the forms of the topic to look for in text: its
+// inflections, or just its word.
+func (t Topic) Words() []string {
+	if len(t.Forms) > 0 {
+		return t.Forms
+	}
+	return []string{t.Word}
+}
+
+// TopicsDir is the subdirectory of a page holding its topic pages.
+const TopicsDir = "topics/"
+
+// TopicPath returns the path of the topic page of word on the page at
+// pagePath, e.g. "/moments/topics/golang/".
+func TopicPath(pagePath, word string) string {
+	return pagePath + TopicsDir + TagSlug(word) + "/"
+}
+
+// TopicIndexPath is the path of the site-wide topic index.
+const TopicIndexPath = "/topics/"
+
+// SiteTopic is a topic of the site-wide topic index, collecting the posts
+// and date sections that mention it.
+type SiteTopic struct {
+	Word     string
+	Slug     string // Anchor of the topic on the index
+	Count    int    // Total count across pages and posts
+	Posts    []Post
+	Sections []DateSection
+}
+
+// TopicSettings tunes topic extraction for a page listed in topics.pages.
+// Zero fields use the defaults.
+type TopicSettings struct {
+	Limit     int      // Maximum number of topics (default 18)
+	MinLength int      // Minimum word length in characters (default 3)
+	MinCount  int      // Minimum number of occurrences (default 2)
+	Lang      string   // Language of the stop words and stemming (default "en")
+	Stem      bool     // Group inflections under their most frequent form
+	StopWords []string // Extra stop words for all languages
+	Mode      string   // "frequency" (default) or "tfidf" for phrases weighted against the site
+}
+
+// TopicMention is a date section mentioning a topic, with a snippet of its
+// text around the first mention.
+type TopicMention struct {
+	Section DateSection
+	Snippet string
+}
+
+// Media is an audio or video file attached to a post via frontmatter.
+type Media struct {
+	Path   string // Asset path from frontmatter, e.g. "assets/episode-1.mp3"
+	Type   string // MIME type
+	Length int64  // File size in bytes
+}
+
+// Podcast holds podcast channel metadata for the RSS feed.
+type Podcast struct {
+	Author      string
+	Email       string
+	Image       string
+	Category    string
+	Subcategory string
+	Explicit    bool
+	Type        string // "episodic" or "serial"
+}
+
+// Backlink references a page or post that links to the current page.
+type Backlink struct {
+	Title string
+	URL   string
+}
+
+// RobotsGroup is a group of robots.txt rules for a user agent.
+type RobotsGroup struct {
+	UserAgent string   // User agent the rules apply to, or "*" for all
+	Allow     []string // Path prefixes the agent may crawl
+	Disallow  []string // Path prefixes the agent may not crawl
 }
 
 // Analytics holds analytics configuration.
@@ -50,54 +227,110 @@ type Page struct {
 	Title             string
 	Slug              string
 	Content           string
+	Markdown          string    // Markdown source of the content, with includes expanded and without frontmatter
+	Text              string    // Plain text of the content, one line per block, without code
+	Headings          []Heading // Headings of the content in document order
 	Path              string
-	DateAnchors       []string    // Date anchors for navigation (e.g., "2026-01-26")
-	CurrentMonthDates []string    // Dates from the most recent month
-	ArchivedYears     []YearGroup // Previous months grouped by year for archive navigation
-	Topics            []Topic     // Extracted topic words with frequency counts
+	DateAnchors       []string          // Date anchors for navigation (e.g., "2026-01-26")
+	CurrentMonthDates []string          // Dates from the most recent month
+	ArchivedYears     []YearGroup       // Previous months grouped by year for archive navigation
+	Topics            []Topic           // Extracted topic words with frequency counts
+	Backlinks         []Backlink        // Pages and posts linking here via wiki links
+	ArchivedAnchors   map[string]string // Anchors moved to monthly archive pages, mapped to their paths
+	OnThisDayPath     string            // Path of the page's "on this day" page; empty when disabled
+	Calendar          []CalendarDay     // Days with date sections for the calendar heatmap, oldest first
+	Lang              string            // Language tag from frontmatter, e.g. "nl"
+	Description       string            // Meta description from frontmatter
+	Canonical         string            // Canonical URL from frontmatter, absolute or root-relative
+	NoIndex           bool              // Keep the page out of search engines and the sitemap
+	Image             string            // Social card image from frontmatter, relative to the page
+	Draft             bool              // Draft from frontmatter; drafts get no markdown copy
+	MarkdownPath      string            // Path of the page's markdown copy, e.g. "/about/index.md"; empty without one
+	Warnings          []string          // Problems that did not fail parsing, as "path: message"
+}
+
+// Heading is a heading of a page's content.
+type Heading struct {
+	Level int    // 1 for h1 through 6 for h6
+	ID    string // Anchor ID of the heading
+	Text  string // Plain text of the heading
 }
 
 // Post represents a blog post with date and summary.
 type Post struct {
 	Page
-	Date      time.Time
-	Summary   string
-	WordCount int
-	Assets    []string // Referenced asset paths from markdown
+	Date        time.Time
+	Summary     string // Summary from frontmatter, or derived from the first paragraph
+	Excerpt     string // HTML before the <!--more--> marker; empty without one
+	WordCount   int
+	ReadingTime int      // Estimated reading time in minutes
+	Assets      []string // Referenced asset paths from markdown
+	Author      string   // Author from frontmatter, overriding the site author
+	Tags        []string // Tags from frontmatter, published as feed categories
+	GUID        string   // Feed ID from frontmatter, overriding the post URL
+	Audio       *Media   // Audio episode from frontmatter
+	Video       *Media   // Video from frontmatter
+	Duration    string   // Media duration from frontmatter, e.g. "32:15"
+	Episode     int      // Episode number from frontmatter
 }
 
 // Site represents the complete site with all pages and posts.
 type Site struct {
-	Title         string
-	Description   string
-	BaseURL       string
-	Author        string
-	Logo          string
-	OGImage       string
-	Favicon       string
-	Navigation    []NavItem
-	Pages         []Page
-	Posts         []Post
-	Analytics     Analytics
-	FooterContent string
+	Title           string
+	Description     string
+	BaseURL         string
+	Author          string
+	Logo            string
+	OGImage         string
+	Favicon         string
+	Navigation      []NavItem
+	Pages           []Page
+	Posts           []Post
+	Analytics       Analytics
+	FooterContent   string
+	FeedFormats     []string // Feed formats to publish; RSS when empty
+	FeedLimit       int      // Maximum feed items; DefaultFeedLimit when zero
+	FeedSummaryOnly bool     // Publish summaries instead of full content in feeds
+	FeedExcerpts    bool     // Publish excerpts instead of full content in feeds, where set
+	Podcast         *Podcast // Podcast channel metadata; nil when not a podcast
+	FeedPages       []string // Pages whose date sections are published in feeds
 }
 
 // Config holds site configuration loaded from ssg.yaml.
 type Config struct {
-	Title       string
-	Description string
-	BaseURL     string
-	Author      string
-	Logo        string
-	OGImage     string
-	Favicon     string
-	ContentDir  string
-	OutputDir   string
-	AssetsDir   string
-	Navigation  []NavItem
-	Analytics   Analytics
-	FeedPages   []string
-	TopicPages  []string
+	Title           string
+	Description     string
+	BaseURL         string
+	Author          string
+	Logo            string
+	OGImage         string
+	Favicon         string
+	ContentDir      string
+	OutputDir       string
+	AssetsDir       string
+	Navigation      []NavItem
+	Analytics       Analytics
+	FeedPages       []string
+	FeedTitle       string // feed.title template for date section items
+	FeedFormats     []string
+	FeedLimit       int
+	FeedSummaryOnly bool
+	FeedExcerpts    bool
+	Podcast         *Podcast
+	TopicPages      []string
+	TopicSettings   map[string]TopicSettings // Extraction settings by topics.pages path
+	TopicStopWords  string                   // File of extra stop words for topic extraction
+	TopicPosts      *TopicSettings           // Extraction settings for blog posts; nil when disabled
+	ArchivePages    []string
+	OnThisDayPages  []string
+	Figures         bool
+	WikiLinks       bool
+	Math            bool          // Render $...$ and $$...$$ LaTeX as MathML
+	WordsPerMinute  int           // Reading speed of post reading times; 200 when zero
+	Robots          []RobotsGroup // robots.txt rules; all agents allowed when empty
+	RobotsBlockAI   bool          // Disallow known AI crawlers in robots.txt
+	SitemapExclude  []string      // Globs of site paths left out of the sitemap
+	LLMsFull        bool          // Write llms-full.txt with the markdown of all pages and posts
 }
 
 // FaviconMIMEType returns the MIME type for the favicon based on file extension.
@@ -115,6 +348,201 @@ func (s Site) FaviconMIMEType() string {
 	}
 }
 
+// FeedLinks returns the links of the site's feed formats, in configuration order.
+// Unknown formats are skipped; RSS is returned when no formats are set.
+func (s Site) FeedLinks() []FeedLink {
+	if len(s.FeedFormats) == 0 {
+		link, _ := FeedLinkFor(FeedRSS)
+		return []FeedLink{link}
+	}
+	var links []FeedLink
+	for _, format := range s.FeedFormats {
+		if link, ok := FeedLinkFor(format); ok {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// SectionFeedFile is the file name of per-section RSS feeds.
+const SectionFeedFile = "index.xml"
+
+// SectionFeedLink returns the RSS feed link for the section at sectionPath,
+// e.g. "/blog/", "/tags/go/" or a configured feed page like "/now/".
+// It reports false when the section has no feed.
+func (s Site) SectionFeedLink(sectionPath string) (FeedLink, bool) {
+	sectionPath = normalizeSectionPath(sectionPath)
+	link := FeedLink{Format: FeedRSS, Type: "application/rss+xml", Href: sectionPath + SectionFeedFile}
+
+	if sectionPath == "/blog/" && len(s.Posts) > 0 {
+		link.Title = "Blog"
+		return link, true
+	}
+	if slug, ok := strings.CutPrefix(sectionPath, "/tags/"); ok {
+		if tag, ok := s.tagForSlug(strings.TrimSuffix(slug, "/")); ok {
+			link.Title = "Tag: " + tag
+			return link, true
+		}
+	}
+	for _, feedPage := range s.FeedPages {
+		if normalizeSectionPath(feedPage) != sectionPath {
+			continue
+		}
+		for _, page := range s.Pages {
+			if page.Path == sectionPath {
+				link.Title = page.Title
+				return link, true
+			}
+		}
+	}
+	return FeedLink{}, false
+}
+
+// tagForSlug returns the first post tag with the given slug.
+func (s Site) tagForSlug(slug string) (string, bool) {
+	for _, post := range s.Posts {
+		for _, tag := range post.Tags {
+			if TagSlug(tag) == slug {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
+
+// normalizeSectionPath returns sectionPath with leading and trailing slashes.
+func normalizeSectionPath(sectionPath string) string {
+	if !strings.HasPrefix(sectionPath, "/") {
+		sectionPath = "/" + sectionPath
+	}
+	if !strings.HasSuffix(sectionPath, "/") {
+		sectionPath += "/"
+	}
+	return sectionPath
+}
+
+// MatchPath reports whether the site path sitePath, such as "/tags/go/",
+// matches the glob pattern. Patterns match whole path segments: * and the
+// other [path.Match] syntax stay within a segment, and a ** segment matches
+// any number of segments. Leading and trailing slashes are ignored, so
+// "/tags/**" matches "/tags/" and everything below it.
+func MatchPath(pattern, sitePath string) bool {
+	return matchSegments(pathSegments(pattern), pathSegments(sitePath))
+}
+
+// ValidatePathPattern reports whether pattern is a well-formed glob for
+// [MatchPath].
+func ValidatePathPattern(pattern string) error {
+	for _, segment := range pathSegments(pattern) {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pathSegments splits a site path into its segments, without the leading
+// and trailing slashes.
+func pathSegments(sitePath string) []string {
+	trimmed := strings.Trim(sitePath, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+// matchSegments matches path segments against pattern segments.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(segments); i >= 0; i-- {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// TagGroup holds the posts carrying a tag.
+type TagGroup struct {
+	Tag   string
+	Slug  string
+	Posts []Post
+}
+
+// TagPath returns the site-relative path of the tag's section, e.g. "/tags/go/".
+func (g TagGroup) TagPath() string {
+	return "/tags/" + g.Slug + "/"
+}
+
+// TagGroups groups the site's posts by tag slug, in slug order. Tags that
+// differ only in case or punctuation share a group named after their first use.
+func (s Site) TagGroups() []TagGroup {
+	var groups []TagGroup
+	index := make(map[string]int)
+	for _, post := range s.Posts {
+		seen := make(map[string]bool)
+		for _, tag := range post.Tags {
+			slug := TagSlug(tag)
+			if slug == "" || seen[slug] {
+				continue
+			}
+			seen[slug] = true
+			i, ok := index[slug]
+			if !ok {
+				i = len(groups)
+				index[slug] = i
+				groups = append(groups, TagGroup{Tag: tag, Slug: slug})
+			}
+			groups[i].Posts = append(groups[i].Posts, post)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Slug < groups[j].Slug
+	})
+	return groups
+}
+
+// TagSlug converts a tag to its URL slug: lowercase letters and digits,
+// with runs of other characters replaced by a single hyphen.
+func TagSlug(tag string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(tag) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+	return b.String()
+}
+
+// FeedItemLimit returns the maximum number of feed items to publish.
+func (s Site) FeedItemLimit() int {
+	if s.FeedLimit > 0 {
+		return s.FeedLimit
+	}
+	return DefaultFeedLimit
+}
+
 // PostFeedAdapter wraps a Post to implement FeedItem with site context.
 type PostFeedAdapter struct {
 	Post    *Post
@@ -136,34 +564,137 @@ func (p PostFeedAdapter) FeedContent() string {
 	return p.Post.Content
 }
 
+// FeedSummary returns the post's summary.
+func (p PostFeedAdapter) FeedSummary() string {
+	return p.Post.Summary
+}
+
+// FeedExcerpt returns the post's HTML excerpt.
+func (p PostFeedAdapter) FeedExcerpt() string {
+	return p.Post.Excerpt
+}
+
 // FeedDate returns the post's publication date.
 func (p PostFeedAdapter) FeedDate() time.Time {
 	return p.Post.Date
 }
 
-// FeedGUID returns the unique identifier (same as link for posts).
+// FeedGUID returns the frontmatter guid if set, otherwise the post link.
 func (p PostFeedAdapter) FeedGUID() string {
+	if p.Post.GUID != "" {
+		return p.Post.GUID
+	}
 	return p.FeedLink()
 }
 
+// FeedAuthor returns the post's frontmatter author.
+func (p PostFeedAdapter) FeedAuthor() string {
+	return p.Post.Author
+}
+
+// FeedCategories returns the post's tags.
+func (p PostFeedAdapter) FeedCategories() []string {
+	return p.Post.Tags
+}
+
+// FeedMedia returns the post's audio, or its video when it has no audio.
+// Media files are published next to the post.
+func (p PostFeedAdapter) FeedMedia() *FeedMedia {
+	media := p.Post.Audio
+	if media == nil {
+		media = p.Post.Video
+	}
+	if media == nil {
+		return nil
+	}
+	return &FeedMedia{
+		URL:      p.FeedLink() + path.Base(media.Path),
+		Length:   media.Length,
+		Type:     media.Type,
+		Duration: p.Post.Duration,
+		Episode:  p.Post.Episode,
+	}
+}
+
+// DefaultFeedTitle is the feed.title template for date section feed items:
+// the page title followed by the section title, or by the section date when
+// the section has no title.
+const DefaultFeedTitle = `{{.Page}} - {{if .Title}}{{.Title}}{{else}}{{.Date}}{{with .Time}} {{.}}{{end}}{{end}}`
+
+// defaultFeedTitle is the parsed DefaultFeedTitle template.
+var defaultFeedTitle = template.Must(template.New("feed.title").Parse(DefaultFeedTitle))
+
+// FeedTitleData holds the fields available to feed.title templates.
+type FeedTitleData struct {
+	Page  string // Page title, e.g. "Now"
+	Title string // First heading or first sentence of the section
+	Date  string // Section date, e.g. "January 2, 2006"
+	Time  string // Section time, e.g. "14:30"; empty for date-only sections
+}
+
+// ParseFeedTitle parses a feed.title template and checks that it executes
+// with FeedTitleData.
+func ParseFeedTitle(text string) (*template.Template, error) {
+	tmpl, err := template.New("feed.title").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(io.Discard, FeedTitleData{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
 // DateSection represents a date-anchored section from a page for the RSS feed.
 type DateSection struct {
-	PageTitle string
-	PagePath  string
-	Date      time.Time
-	Anchor    string
-	Content   string
-	BaseURL   string
+	PageTitle     string
+	PagePath      string
+	Date          time.Time
+	HasTime       bool // Date includes a time from a heading like ## *2026-01-27 14:30*
+	Anchor        string
+	ArchivePath   string // Monthly archive page holding the section; empty when on the page
+	Content       string
+	Title         string             // First heading or first sentence of the section
+	Words         int                // Length of the section in words
+	TitleTemplate *template.Template // feed.title template; DefaultFeedTitle when nil
+	BaseURL       string
 }
 
-// FeedTitle returns the title in format "{PageTitle} - {Month} {Day}, {Year}".
+// FeedTitle returns the title from the section's title template, by default
+// "{PageTitle} - {Title}", or "{PageTitle} - {Month} {Day}, {Year}" when the
+// section has no title.
 func (d DateSection) FeedTitle() string {
-	return d.PageTitle + " - " + d.Date.Format("January 2, 2006")
+	tmpl := d.TitleTemplate
+	if tmpl == nil {
+		tmpl = defaultFeedTitle
+	}
+	data := FeedTitleData{
+		Page:  d.PageTitle,
+		Title: d.Title,
+		Date:  d.Date.Format("January 2, 2006"),
+	}
+	if d.HasTime {
+		data.Time = d.Date.Format("15:04")
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return d.PageTitle + " - " + data.Date
+	}
+	return buf.String()
 }
 
-// FeedLink returns the absolute URL to the page with the date anchor.
+// URL returns the site-relative URL of the section: its page, or its
+// monthly archive page when the section was moved there, with the date anchor.
+func (d DateSection) URL() string {
+	if d.ArchivePath != "" {
+		return d.ArchivePath + "#" + d.Anchor
+	}
+	return d.PagePath + "#" + d.Anchor
+}
+
+// FeedLink returns the absolute URL of the section.
 func (d DateSection) FeedLink() string {
-	return d.BaseURL + d.PagePath + "#" + d.Anchor
+	return d.BaseURL + d.URL()
 }
 
 // FeedContent returns the section's HTML content.
@@ -171,12 +702,53 @@ func (d DateSection) FeedContent() string {
 	return d.Content
 }
 
+// FeedSummary returns an empty string; date sections have no summary.
+func (d DateSection) FeedSummary() string {
+	return ""
+}
+
+// FeedExcerpt returns an empty string; date sections have no excerpt.
+func (d DateSection) FeedExcerpt() string {
+	return ""
+}
+
 // FeedDate returns the date of this section.
 func (d DateSection) FeedDate() time.Time {
 	return d.Date
 }
 
-// FeedGUID returns the unique identifier (same as link).
+// FeedGUID returns the unique identifier: the section's URL on its page,
+// which stays stable when the section moves to a monthly archive page.
 func (d DateSection) FeedGUID() string {
-	return d.FeedLink()
+	return d.BaseURL + d.PagePath + "#" + d.Anchor
+}
+
+// FeedAuthor returns an empty string; date sections use the site author.
+func (d DateSection) FeedAuthor() string {
+	return ""
+}
+
+// FeedCategories returns nil; date sections have no categories.
+func (d DateSection) FeedCategories() []string {
+	return nil
+}
+
+// FeedMedia returns nil; date sections have no media.
+func (d DateSection) FeedMedia() *FeedMedia {
+	return nil
+}
+
+// OnThisDay returns the sections dated on the month and day of today in
+// earlier years, newest first.
+func OnThisDay(sections []DateSection, today time.Time) []DateSection {
+	var matches []DateSection
+	for _, s := range sections {
+		if s.Date.Month() == today.Month() && s.Date.Day() == today.Day() && s.Date.Year() < today.Year() {
+			matches = append(matches, s)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Date.After(matches[j].Date)
+	})
+	return matches
 }
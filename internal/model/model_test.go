@@ -1,6 +1,7 @@
 package model_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -226,6 +227,90 @@ func TestPostFeedAdapter_ImplementsFeedItem(t *testing.T) {
 	}
 }
 
+func TestPostFeedAdapter_FrontmatterOverrides(t *testing.T) {
+	t.Parallel()
+
+	post := &model.Post{
+		Page:    model.Page{Title: "Renamed", Slug: "new-slug"},
+		Summary: "A summary",
+		Author:  "Guest Writer",
+		Tags:    []string{"go"},
+		GUID:    "https://example.com/blog/old-slug/",
+	}
+	adapter := model.PostFeedAdapter{Post: post, BaseURL: "https://example.com"}
+
+	if got := adapter.FeedGUID(); got != "https://example.com/blog/old-slug/" {
+		t.Errorf("FeedGUID() = %q, want frontmatter guid", got)
+	}
+	if got := adapter.FeedLink(); got != "https://example.com/blog/new-slug/" {
+		t.Errorf("FeedLink() = %q, want current slug", got)
+	}
+	if got := adapter.FeedSummary(); got != "A summary" {
+		t.Errorf("FeedSummary() = %q, want %q", got, "A summary")
+	}
+	if got := adapter.FeedAuthor(); got != "Guest Writer" {
+		t.Errorf("FeedAuthor() = %q, want %q", got, "Guest Writer")
+	}
+	if got := adapter.FeedCategories(); len(got) != 1 || got[0] != "go" {
+		t.Errorf("FeedCategories() = %v, want [go]", got)
+	}
+}
+
+func TestPostFeedAdapter_FeedMedia(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		post model.Post
+		want *model.FeedMedia
+	}{
+		{
+			name: "no media",
+			post: model.Post{Page: model.Page{Slug: "plain"}},
+		},
+		{
+			name: "audio preferred over video",
+			post: model.Post{
+				Page:     model.Page{Slug: "episode-1"},
+				Audio:    &model.Media{Path: "assets/episode-1.mp3", Type: "audio/mpeg", Length: 1024},
+				Video:    &model.Media{Path: "assets/episode-1.mp4", Type: "video/mp4", Length: 4096},
+				Duration: "32:15",
+				Episode:  1,
+			},
+			want: &model.FeedMedia{URL: "https://example.com/blog/episode-1/episode-1.mp3", Length: 1024, Type: "audio/mpeg", Duration: "32:15", Episode: 1},
+		},
+		{
+			name: "video only",
+			post: model.Post{
+				Page:  model.Page{Slug: "clip"},
+				Video: &model.Media{Path: "assets/clip.webm", Type: "video/webm", Length: 2048},
+			},
+			want: &model.FeedMedia{URL: "https://example.com/blog/clip/clip.webm", Length: 2048, Type: "video/webm"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := model.PostFeedAdapter{Post: &tt.post, BaseURL: "https://example.com"}
+			got := adapter.FeedMedia()
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("FeedMedia() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSite_FeedItemLimit(t *testing.T) {
+	t.Parallel()
+	if got := (model.Site{}).FeedItemLimit(); got != model.DefaultFeedLimit {
+		t.Errorf("FeedItemLimit() = %d, want default %d", got, model.DefaultFeedLimit)
+	}
+	if got := (model.Site{FeedLimit: 5}).FeedItemLimit(); got != 5 {
+		t.Errorf("FeedItemLimit() = %d, want 5", got)
+	}
+}
+
 func TestDateSection_ImplementsFeedItem(t *testing.T) {
 	t.Parallel()
 
@@ -257,3 +342,322 @@ func TestDateSection_ImplementsFeedItem(t *testing.T) {
 		t.Errorf("FeedGUID() = %q, want %q", got, "https://example.com/moments/#2026-01-27")
 	}
 }
+
+func TestDateSection_FeedTitle(t *testing.T) {
+	t.Parallel()
+
+	custom, err := model.ParseFeedTitle("{{.Date}} {{.Time}}: {{.Title}}")
+	if err != nil {
+		t.Fatalf("ParseFeedTitle() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		section model.DateSection
+		want    string
+	}{
+		{
+			name:    "titled section",
+			section: model.DateSection{PageTitle: "Now", Title: "Started learning Rust.", Date: time.Date(2026, 1, 27, 0, 0, 0, 0, time.UTC)},
+			want:    "Now - Started learning Rust.",
+		},
+		{
+			name:    "untitled section with time",
+			section: model.DateSection{PageTitle: "Now", Date: time.Date(2026, 1, 27, 14, 30, 0, 0, time.UTC), HasTime: true},
+			want:    "Now - January 27, 2026 14:30",
+		},
+		{
+			name: "custom template",
+			section: model.DateSection{
+				PageTitle:     "Now",
+				Title:         "Coffee",
+				Date:          time.Date(2026, 1, 27, 9, 5, 0, 0, time.UTC),
+				HasTime:       true,
+				TitleTemplate: custom,
+			},
+			want: "January 27, 2026 09:05: Coffee",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.section.FeedTitle(); got != tt.want {
+				t.Errorf("FeedTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateSection_ArchivedSectionLinks(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	archivePath := model.ArchivePath("/moments/", date)
+	if archivePath != "/moments/2025/03/" {
+		t.Fatalf("ArchivePath() = %q, want /moments/2025/03/", archivePath)
+	}
+
+	section := model.DateSection{
+		PageTitle:   "Moments",
+		PagePath:    "/moments/",
+		Date:        date,
+		Anchor:      "2025-03-14",
+		ArchivePath: archivePath,
+		BaseURL:     "https://example.com",
+	}
+	if got := section.FeedLink(); got != "https://example.com/moments/2025/03/#2025-03-14" {
+		t.Errorf("FeedLink() = %q, want archive page URL", got)
+	}
+	if got := section.FeedGUID(); got != "https://example.com/moments/#2025-03-14" {
+		t.Errorf("FeedGUID() = %q, want original page URL", got)
+	}
+}
+
+func TestOnThisDay(t *testing.T) {
+	t.Parallel()
+
+	section := func(anchor string, date time.Time) model.DateSection {
+		return model.DateSection{Anchor: anchor, Date: date}
+	}
+	sections := []model.DateSection{
+		section("2023-03-14", time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC)),
+		section("2026-03-14", time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)),
+		section("2025-03-14-0930", time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)),
+		section("2025-03-15", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)),
+		section("2024-04-14", time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC)),
+	}
+
+	got := model.OnThisDay(sections, time.Date(2026, 3, 14, 8, 0, 0, 0, time.UTC))
+	var anchors []string
+	for _, s := range got {
+		anchors = append(anchors, s.Anchor)
+	}
+	if strings.Join(anchors, ",") != "2025-03-14-0930,2023-03-14" {
+		t.Errorf("OnThisDay() = %v, want [2025-03-14-0930 2023-03-14]", anchors)
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	t.Parallel()
+
+	sections := []model.DateSection{
+		{PagePath: "/moments/", Anchor: "2026-03-14-1800", Date: time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC), Words: 30},
+		{PagePath: "/moments/", Anchor: "2026-03-14-0930", Date: time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC), Words: 12},
+		{PagePath: "/moments/", Anchor: "2025-12-31", Date: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), Words: 5, ArchivePath: "/moments/2025/12/"},
+	}
+
+	got := model.Calendar(sections)
+	want := []model.CalendarDay{
+		{Date: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), Words: 5, URL: "/moments/2025/12/#2025-12-31"},
+		{Date: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), Words: 42, URL: "/moments/#2026-03-14-1800"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Calendar() returned %d days, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Date.Equal(want[i].Date) || got[i].Words != want[i].Words || got[i].URL != want[i].URL {
+			t.Errorf("Calendar()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTopicPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pagePath string
+		word     string
+		want     string
+	}{
+		{"/moments/", "golang", "/moments/topics/golang/"},
+		{"/now/", "self-hosting", "/now/topics/self-hosting/"},
+		{"/moments/", "café", "/moments/topics/café/"},
+	}
+
+	for _, tt := range tests {
+		if got := model.TopicPath(tt.pagePath, tt.word); got != tt.want {
+			t.Errorf("TopicPath(%q, %q) = %q, want %q", tt.pagePath, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestParseFeedTitle_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{"{{.Title", "{{.Heading}}"} {
+		if _, err := model.ParseFeedTitle(text); err == nil {
+			t.Errorf("ParseFeedTitle(%q) error = nil, want error", text)
+		}
+	}
+}
+
+func TestSite_FeedLinks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		formats []string
+		want    []string
+	}{
+		{
+			name:    "defaults to rss",
+			formats: nil,
+			want:    []string{"/feed.xml"},
+		},
+		{
+			name:    "keeps configured order",
+			formats: []string{"json", "atom", "rss"},
+			want:    []string{"/feed.json", "/atom.xml", "/feed.xml"},
+		},
+		{
+			name:    "skips unknown formats",
+			formats: []string{"atom", "gopher"},
+			want:    []string{"/atom.xml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			links := model.Site{FeedFormats: tt.formats}.FeedLinks()
+			if len(links) != len(tt.want) {
+				t.Fatalf("FeedLinks() returned %d links, want %d", len(links), len(tt.want))
+			}
+			for i, link := range links {
+				if link.Href != tt.want[i] {
+					t.Errorf("FeedLinks()[%d].Href = %q, want %q", i, link.Href, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTagSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "go", want: "go"},
+		{tag: "Static Sites", want: "static-sites"},
+		{tag: "C++ & Go!", want: "c-go"},
+		{tag: "Café", want: "café"},
+		{tag: "!!!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+			if got := model.TagSlug(tt.tag); got != tt.want {
+				t.Errorf("TagSlug(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "/about/", path: "/about/", want: true},
+		{pattern: "/about", path: "/about/", want: true},
+		{pattern: "/tags/*", path: "/tags/go/", want: true},
+		{pattern: "/tags/*", path: "/tags/", want: false},
+		{pattern: "/tags/*", path: "/tags/go/feed/", want: false},
+		{pattern: "/tags/**", path: "/tags/", want: true},
+		{pattern: "/tags/**", path: "/tags/go/feed/", want: true},
+		{pattern: "/**/topics/*", path: "/moments/topics/go/", want: true},
+		{pattern: "/moments/20??/*", path: "/moments/2025/03/", want: true},
+		{pattern: "/moments/20??/*", path: "/moments/", want: false},
+		{pattern: "/", path: "/", want: true},
+		{pattern: "/**", path: "/", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := model.MatchPath(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePathPattern(t *testing.T) {
+	t.Parallel()
+
+	if err := model.ValidatePathPattern("/tags/**/[a-z]*"); err != nil {
+		t.Errorf("ValidatePathPattern() error = %v", err)
+	}
+	if err := model.ValidatePathPattern("/tags/[a-"); err == nil {
+		t.Error("ValidatePathPattern() accepted a malformed pattern")
+	}
+}
+
+func TestSite_TagGroups(t *testing.T) {
+	t.Parallel()
+
+	site := model.Site{Posts: []model.Post{
+		{Page: model.Page{Slug: "newer"}, Tags: []string{"Go", "web"}},
+		{Page: model.Page{Slug: "older"}, Tags: []string{"go", "Go"}},
+	}}
+
+	groups := site.TagGroups()
+	if len(groups) != 2 {
+		t.Fatalf("TagGroups() returned %d groups, want 2", len(groups))
+	}
+	if groups[0].Tag != "Go" || groups[0].Slug != "go" || len(groups[0].Posts) != 2 {
+		t.Errorf("groups[0] = %s/%s with %d posts, want Go/go with 2 posts", groups[0].Tag, groups[0].Slug, len(groups[0].Posts))
+	}
+	if groups[1].Slug != "web" || len(groups[1].Posts) != 1 {
+		t.Errorf("groups[1] = %s with %d posts, want web with 1 post", groups[1].Slug, len(groups[1].Posts))
+	}
+	if got := groups[0].TagPath(); got != "/tags/go/" {
+		t.Errorf("TagPath() = %q, want /tags/go/", got)
+	}
+}
+
+func TestSite_SectionFeedLink(t *testing.T) {
+	t.Parallel()
+
+	site := model.Site{
+		Pages:     []model.Page{{Title: "Now", Path: "/now/"}, {Title: "About", Path: "/about/"}},
+		Posts:     []model.Post{{Page: model.Page{Slug: "post"}, Tags: []string{"Static Sites"}}},
+		FeedPages: []string{"now"},
+	}
+
+	tests := []struct {
+		path      string
+		wantTitle string
+		wantHref  string
+	}{
+		{path: "/blog/", wantTitle: "Blog", wantHref: "/blog/index.xml"},
+		{path: "/tags/static-sites/", wantTitle: "Tag: Static Sites", wantHref: "/tags/static-sites/index.xml"},
+		{path: "/now/", wantTitle: "Now", wantHref: "/now/index.xml"},
+		{path: "/about/"},
+		{path: "/tags/unknown/"},
+		{path: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			link, ok := site.SectionFeedLink(tt.path)
+			if ok != (tt.wantHref != "") {
+				t.Fatalf("SectionFeedLink(%q) ok = %v, want %v", tt.path, ok, tt.wantHref != "")
+			}
+			if link.Title != tt.wantTitle || link.Href != tt.wantHref {
+				t.Errorf("SectionFeedLink(%q) = %q %q, want %q %q", tt.path, link.Title, link.Href, tt.wantTitle, tt.wantHref)
+			}
+		})
+	}
+
+	if _, ok := (model.Site{}).SectionFeedLink("/blog/"); ok {
+		t.Error("SectionFeedLink(/blog/) without posts should report false")
+	}
+}
@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/jeroendee/ssg/internal/model"
+	"github.com/jeroendee/ssg/internal/textcount"
+	"github.com/yuin/goldmark/ast"
+	gmparser "github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// Analysis is the result of a single pass over a markdown document: its HTML
+// and everything derived from the same syntax tree.
+type Analysis struct {
+	HTML        string
+	Text        string           // Plain text, one line per block, without code blocks, math or image alt text
+	Words       textcount.Counts // Word counts of Text
+	Assets      []string         // Image paths under assets/, in document order
+	Headings    []model.Heading  // Headings in document order
+	DateAnchors []string         // Anchors of date headings, such as 2026-01-27-1430
+	Summary     string           // Text of the first paragraph, cut at a word boundary
+	Excerpt     string           // HTML before the <!--more--> marker; empty without one
+	Warnings    []string         // Problems that did not fail the conversion, such as math kept as text
+
+	dateOffsets []int // Offsets in the markdown of the lines of the date headings
+}
+
+// maxSummary is the maximum length in runes of a derived summary, about
+// the length search engines show of a meta description.
+const maxSummary = 160
+
+// dateHeadingRegex matches the italic text of a date heading, with an
+// optional time.
+var dateHeadingRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})(?: (\d{2}):(\d{2}))?$`)
+
+// Analyze converts markdown to HTML and derives its text, word counts,
+// asset references, headings, date anchors, summary and excerpt in the
+// same pass.
+func Analyze(markdown string) (*Analysis, error) {
+	return analyze(markdown, "", Options{})
+}
+
+// analyze parses markdown once with opts, renders the syntax tree to HTML
+// and walks it for the rest of the analysis. baseDir is the directory of the
+// source file, used to resolve relative image paths.
+func analyze(markdown, baseDir string, opts Options) (*Analysis, error) {
+	converter := converterFor(opts)
+
+	ctx := gmparser.NewContext()
+	ctx.Set(baseDirKey, baseDir)
+	ctx.Set(assetsDirKey, opts.AssetsDir)
+
+	source := []byte(markdown)
+	doc := converter.Parser().Parse(text.NewReader(source), gmparser.WithContext(ctx))
+	hasMore := markMore(doc, source)
+
+	var buf bytes.Buffer
+	if err := converter.Renderer().Render(&buf, source, doc); err != nil {
+		return nil, fmt.Errorf("markdown conversion failed: %w", err)
+	}
+
+	a := &Analysis{
+		HTML:        buf.String(),
+		Assets:      []string{},
+		DateAnchors: []string{},
+	}
+	if hasMore {
+		a.Excerpt, _, _ = strings.Cut(a.HTML, moreMarker)
+		a.Excerpt = strings.TrimSpace(a.Excerpt)
+	}
+	var body strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if n.Type() == ast.TypeBlock && body.Len() > 0 && !strings.HasSuffix(body.String(), "\n") {
+			body.WriteByte('\n')
+		}
+		switch n := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *mathBlock:
+			a.addWarning(n.warning)
+			return ast.WalkSkipChildren, nil
+		case *mathInline:
+			a.addWarning(n.warning)
+			return ast.WalkSkipChildren, nil
+		case *ast.Image:
+			if dest := string(n.Destination); strings.HasPrefix(dest, "assets/") && !strings.ContainsAny(dest, " \t") {
+				a.Assets = append(a.Assets, dest)
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.Heading:
+			a.addHeading(n, source)
+		case *ast.Paragraph:
+			if a.Summary == "" {
+				summary := strings.Join(strings.Fields(html.UnescapeString(inlineText(n, source))), " ")
+				a.Summary = truncateWords(summary, maxSummary)
+			}
+		case *ast.Text, *ast.String, *ast.AutoLink, *wikiLink:
+			writeInlineText(&body, n, source)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	a.Text = html.UnescapeString(body.String())
+	a.Words = textcount.Count(a.Text)
+	return a, nil
+}
+
+// addWarning records warning, if any.
+func (a *Analysis) addWarning(warning string) {
+	if warning != "" {
+		a.Warnings = append(a.Warnings, warning)
+	}
+}
+
+// addHeading records heading n, and its date anchor when its text starts
+// with an italic date such as *2026-01-27 14:30*.
+func (a *Analysis) addHeading(n *ast.Heading, source []byte) {
+	heading := model.Heading{Level: n.Level, Text: html.UnescapeString(inlineText(n, source))}
+	if id, ok := n.AttributeString("id"); ok {
+		heading.ID = string(attrToBytes(id))
+	}
+	a.Headings = append(a.Headings, heading)
+
+	em, ok := n.FirstChild().(*ast.Emphasis)
+	if !ok || em.Level != 1 {
+		return
+	}
+	var date strings.Builder
+	for c := em.FirstChild(); c != nil; c = c.NextSibling() {
+		writeInlineText(&date, c, source)
+	}
+	if m := dateHeadingRegex.FindStringSubmatch(date.String()); m != nil {
+		anchor := m[1]
+		if m[2] != "" {
+			anchor += "-" + m[2] + m[3]
+		}
+		a.DateAnchors = append(a.DateAnchors, anchor)
+		a.dateOffsets = append(a.dateOffsets, bytes.LastIndexByte(source[:n.Lines().At(0).Start], '\n')+1)
+	}
+}
+
+// inlineText returns the text of the inline nodes within n, skipping code
+// and math like the text of an [Analysis].
+func inlineText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch c.(type) {
+		case *ast.Image, *ast.RawHTML, *mathInline:
+			return ast.WalkSkipChildren, nil
+		}
+		writeInlineText(&b, c, source)
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
+
+// writeInlineText writes the text of inline node n, if it holds any, to b.
+func writeInlineText(b *strings.Builder, n ast.Node, source []byte) {
+	switch n := n.(type) {
+	case *ast.Text:
+		b.Write(n.Segment.Value(source))
+		if n.SoftLineBreak() || n.HardLineBreak() {
+			b.WriteByte(' ')
+		}
+	case *ast.String:
+		b.Write(n.Value)
+	case *ast.AutoLink:
+		b.Write(n.Label(source))
+	case *wikiLink:
+		b.Write(n.Label)
+	}
+}
@@ -1,13 +1,15 @@
 // Package parser handles markdown content parsing with frontmatter extraction.
 //
 // This package converts markdown files into [model.Page] and [model.Post]
-// structures. It supports YAML frontmatter for metadata and converts
-// markdown content to HTML using [github.com/yuin/goldmark].
+// structures. It supports YAML, TOML and JSON frontmatter for metadata and
+// converts markdown content to HTML using [github.com/yuin/goldmark].
 //
 // # Frontmatter Format
 //
-// Frontmatter is delimited by "---" and contains YAML. The [Frontmatter]
-// struct supports these fields:
+// YAML and TOML frontmatter starts on the first line and ends at the first
+// line holding only the closing delimiter: "---" for YAML, "+++" for TOML.
+// JSON frontmatter is an object starting the file, on one line or many.
+// These fields are supported:
 //
 //	---
 //	title: My Page Title
@@ -17,8 +19,19 @@
 //
 //	Markdown content here...
 //
-// Use [ExtractFrontmatter] to separate frontmatter from content, and
-// [MarkdownToHTML] to convert the markdown body to HTML.
+// Pages and posts may set lang, which selects the topic extraction language,
+// and description, canonical, noindex and image, which override their meta
+// description, canonical URL, indexing and social card image, and draft,
+// which keeps them out of the markdown copies. The markdown body, with includes
+// expanded, is kept for the builder's markdown copies.
+// Blog posts may also set author, tags and guid, which are used in feeds,
+// and audio or video with an assets/ path plus duration and episode for
+// media enclosures. Media files must exist; their size and MIME type are
+// read from disk.
+// Parse errors name the file and line, as in "content/blog/x.md:4: ...".
+// Use [StripFrontmatter] to separate the markdown body from frontmatter, and
+// [MarkdownToHTMLWithError] to convert a markdown body to HTML with the
+// default options, which leave math, figures and wiki links as written.
 //
 // # Blog Post Dates
 //
@@ -31,4 +44,80 @@
 // overrides the filename date.
 //
 // Use [ParsePage] for static pages and [ParsePost] for blog posts.
+//
+// # Analysis
+//
+// Markdown is parsed once. [Analyze] renders the syntax tree to HTML and
+// walks the same tree for the plain text, word counts, asset references,
+// headings, date anchors, summary and excerpt, so nothing is re-parsed or re-read from disk.
+// Pages and posts keep the text and headings; the builder extracts topics
+// from the text. The text has one line per block and leaves out code
+// blocks, raw HTML, math and image alt text.
+//
+// Posts without a frontmatter summary are summarized by the text of their
+// first paragraph, cut at a word boundary. A <!--more--> marker on its own
+// line ends the post's excerpt, the HTML above it.
+//
+// Blog posts get a word count and a reading time in minutes, at the
+// [Options] WordsPerMinute. Chinese and Japanese characters count as a word
+// each; code blocks are not counted.
+//
+// # Date Anchors
+//
+// Pages can be divided into dated sections by italic date headings, with
+// an optional time to allow several sections per day:
+//
+//	## *2026-01-27*
+//	## *2026-01-27 14:30*
+//
+// [Analyze] and [ExtractDateAnchors] return their anchors, 2026-01-27 and
+// 2026-01-27-1430, which match the heading IDs in the rendered HTML.
+// [ExtractFeedDateSections] splits rendered HTML into sections titled by
+// their first heading or first sentence, with their length in words, and
+// [ParseDateFromAnchor] converts an anchor back to a time. [SplitArchiveMonths] separates the most recent
+// month from older months for monthly archive pages, and
+// [TrimArchivedMarkdown] drops the older months from the markdown.
+//
+// # Figures
+//
+// With [Options.Figures] enabled, a standalone image becomes a figure
+// captioned by its title:
+//
+//	![Alt text](assets/photo.jpg "Caption")
+//
+// Images get loading="lazy", decoding="async", and width/height read from
+// the image file. Use [ParsePageWithOptions] and [ParsePostWithOptions] to
+// render with options.
+//
+// # Wiki Links
+//
+// With [Options.WikiLinks] enabled, [[Page Title]] and [[slug|label]]
+// render as links with placeholder hrefs. The builder resolves them
+// against all scanned pages and posts with [ResolveWikiLinks], and rewrites
+// them to markdown links for markdown copies with
+// [ResolveMarkdownWikiLinks].
+//
+// # Includes
+//
+// With [Options.ContentDir] set, directives on their own line are expanded
+// before rendering:
+//
+//	{{< include "disclaimer.md" >}}
+//	{{< code "../cmd/ssg/main.go" 10-25 >}}
+//
+// include inserts a markdown file from the content directory's _partials
+// subdirectory; code embeds a source file, or a 1-based inclusive line
+// range of it, as a fenced code block. Paths must stay inside the project
+// root, the parent of the content directory. Include cycles are reported
+// with the full include chain.
+//
+// # Math
+//
+// With [Options.Math] enabled, inline $...$ and display $$...$$ (on their
+// own lines or inline) are rendered as MathML at build time, so no
+// JavaScript is needed. Like Pandoc, the opening $ must be followed by a
+// non-space, the closing $ must follow a non-space and must not be followed
+// by a digit, so "$5 and $10" stays text. Use \$ for a literal dollar sign.
+// Unsupported TeX is kept as text and reported in the Warnings of the page
+// or post, prefixed with its file path.
 package parser
@@ -0,0 +1,235 @@
+package parser
+
+import (
+	"encoding/xml"
+	"image"
+	_ "image/gif"  // register GIF decoder for image dimensions
+	_ "image/jpeg" // register JPEG decoder for image dimensions
+	_ "image/png"  // register PNG decoder for image dimensions
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	gmparser "github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// kindFigure is the node kind for figure blocks.
+var kindFigure = ast.NewNodeKind("Figure")
+
+// figure is a block node wrapping a standalone image with an optional caption.
+type figure struct {
+	ast.BaseBlock
+	Caption []byte
+}
+
+// Kind returns the figure node kind.
+func (n *figure) Kind() ast.NodeKind {
+	return kindFigure
+}
+
+// Dump dumps the figure node for debugging.
+func (n *figure) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Caption": string(n.Caption)}, nil)
+}
+
+// Context keys for resolving image files during transformation.
+var (
+	baseDirKey   = gmparser.NewContextKey()
+	assetsDirKey = gmparser.NewContextKey()
+)
+
+// figureTransformer adds loading hints and dimensions to images and wraps
+// standalone images in figure nodes.
+type figureTransformer struct{}
+
+// Transform rewrites image paragraphs into figures.
+func (t *figureTransformer) Transform(doc *ast.Document, reader text.Reader, pc gmparser.Context) {
+	baseDir, _ := pc.Get(baseDirKey).(string)
+	assetsDir, _ := pc.Get(assetsDirKey).(string)
+
+	var standalone []*ast.Paragraph
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Image:
+			setImageAttributes(n, baseDir, assetsDir)
+		case *ast.Paragraph:
+			if n.ChildCount() == 1 && n.FirstChild().Kind() == ast.KindImage {
+				standalone = append(standalone, n)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, p := range standalone {
+		img := p.FirstChild().(*ast.Image)
+		fig := &figure{Caption: img.Title}
+		img.Title = nil
+		p.RemoveChild(p, img)
+		fig.AppendChild(fig, img)
+		p.Parent().ReplaceChild(p.Parent(), p, fig)
+	}
+}
+
+// setImageAttributes adds lazy loading and intrinsic dimensions to an image.
+func setImageAttributes(img *ast.Image, baseDir, assetsDir string) {
+	img.SetAttributeString("loading", []byte("lazy"))
+	img.SetAttributeString("decoding", []byte("async"))
+
+	path := resolveImagePath(string(img.Destination), baseDir, assetsDir)
+	if path == "" {
+		return
+	}
+	width, height, ok := imageSize(path)
+	if !ok {
+		return
+	}
+	img.SetAttributeString("width", []byte(strconv.Itoa(width)))
+	img.SetAttributeString("height", []byte(strconv.Itoa(height)))
+}
+
+// resolveImagePath maps an image destination to a local file path.
+// Relative paths resolve against baseDir, root-relative paths against assetsDir.
+// Returns empty string for remote URLs or when no directory applies.
+func resolveImagePath(dest, baseDir, assetsDir string) string {
+	if dest == "" || strings.HasPrefix(dest, "//") || strings.Contains(dest, ":") {
+		return ""
+	}
+	if i := strings.IndexAny(dest, "?#"); i >= 0 {
+		dest = dest[:i]
+	}
+	if unescaped, err := url.PathUnescape(dest); err == nil {
+		dest = unescaped
+	}
+
+	if strings.HasPrefix(dest, "/") {
+		if assetsDir == "" {
+			return ""
+		}
+		return filepath.Join(assetsDir, filepath.FromSlash(dest))
+	}
+	if baseDir == "" {
+		return ""
+	}
+	return filepath.Join(baseDir, filepath.FromSlash(dest))
+}
+
+// imageSize reads the pixel dimensions of a PNG, JPEG, GIF or SVG file.
+func imageSize(path string) (width, height int, ok bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, false
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		return svgSize(f)
+	}
+
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
+
+// svgSize reads dimensions from the root svg element's width and height,
+// falling back to its viewBox.
+func svgSize(f *os.File) (width, height int, ok bool) {
+	dec := xml.NewDecoder(f)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0, false
+		}
+		start, isStart := tok.(xml.StartElement)
+		if !isStart {
+			continue
+		}
+		if start.Name.Local != "svg" {
+			return 0, 0, false
+		}
+
+		var w, h, viewBox string
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "width":
+				w = attr.Value
+			case "height":
+				h = attr.Value
+			case "viewBox":
+				viewBox = attr.Value
+			}
+		}
+
+		width, wOK := svgLength(w)
+		height, hOK := svgLength(h)
+		if wOK && hOK {
+			return width, height, true
+		}
+
+		fields := strings.Fields(strings.ReplaceAll(viewBox, ",", " "))
+		if len(fields) != 4 {
+			return 0, 0, false
+		}
+		width, wOK = svgLength(fields[2])
+		height, hOK = svgLength(fields[3])
+		return width, height, wOK && hOK
+	}
+}
+
+// svgLength parses an unitless or pixel length into whole pixels.
+func svgLength(s string) (int, bool) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "px")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return int(v + 0.5), true
+}
+
+// figureRenderer renders figure nodes with a figcaption from the image title.
+type figureRenderer struct {
+	html.Config
+}
+
+// newFigureRenderer returns a new renderer for figure nodes.
+func newFigureRenderer(opts ...html.Option) renderer.NodeRenderer {
+	r := &figureRenderer{
+		Config: html.NewConfig(),
+	}
+	for _, opt := range opts {
+		opt.SetHTMLOption(&r.Config)
+	}
+	return r
+}
+
+// RegisterFuncs registers the figure renderer function.
+func (r *figureRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindFigure, r.renderFigure)
+}
+
+// renderFigure renders a figure with its image and optional caption.
+func (r *figureRenderer) renderFigure(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*figure)
+	if entering {
+		_, _ = w.WriteString("<figure>\n")
+		return ast.WalkContinue, nil
+	}
+	if len(n.Caption) > 0 {
+		_, _ = w.WriteString("\n<figcaption>")
+		r.Writer.Write(w, n.Caption)
+		_, _ = w.WriteString("</figcaption>")
+	}
+	_, _ = w.WriteString("\n</figure>\n")
+	return ast.WalkContinue, nil
+}
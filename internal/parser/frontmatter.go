@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatter holds metadata extracted from markdown files.
+type frontmatter struct {
+	Title    string          `yaml:"title" toml:"title" json:"title"`
+	Summary  string          `yaml:"summary" toml:"summary" json:"summary"`
+	Date     frontmatterDate `yaml:"date" toml:"date" json:"date"`
+	Author   string          `yaml:"author" toml:"author" json:"author"`
+	Tags     []string        `yaml:"tags" toml:"tags" json:"tags"`
+	GUID     string          `yaml:"guid" toml:"guid" json:"guid"`
+	Audio    string          `yaml:"audio" toml:"audio" json:"audio"`
+	Video    string          `yaml:"video" toml:"video" json:"video"`
+	Duration string          `yaml:"duration" toml:"duration" json:"duration"`
+	Episode  int             `yaml:"episode" toml:"episode" json:"episode"`
+	Lang     string          `yaml:"lang" toml:"lang" json:"lang"`
+
+	Description string `yaml:"description" toml:"description" json:"description"`
+	Canonical   string `yaml:"canonical" toml:"canonical" json:"canonical"`
+	NoIndex     bool   `yaml:"noindex" toml:"noindex" json:"noindex"`
+	Image       string `yaml:"image" toml:"image" json:"image"`
+	Draft       bool   `yaml:"draft" toml:"draft" json:"draft"`
+}
+
+// frontmatterDate is a YYYY-MM-DD date string that also accepts native TOML dates.
+type frontmatterDate string
+
+// UnmarshalTOML decodes a TOML string or date.
+func (d *frontmatterDate) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		*d = frontmatterDate(v)
+	case time.Time:
+		*d = frontmatterDate(v.Format("2006-01-02"))
+	default:
+		return fmt.Errorf("date must be a string or date, got %T", v)
+	}
+	return nil
+}
+
+// frontmatterFormat describes a frontmatter block delimited by whole lines.
+type frontmatterFormat struct {
+	name   string
+	delim  string
+	decode func(data []byte, v any) error
+	// errorLine returns the 1-based line within the block at which err occurred,
+	// and the message without position details. Zero means unknown.
+	errorLine func(data []byte, err error) (int, string)
+}
+
+// frontmatterFormats lists the line-delimited frontmatter formats. JSON
+// frontmatter is a single object instead, see extractJSONFrontmatter.
+var frontmatterFormats = []frontmatterFormat{
+	{name: "YAML", delim: "---", decode: yaml.Unmarshal, errorLine: yamlErrorLine},
+	{name: "TOML", delim: "+++", decode: decodeTOML, errorLine: tomlErrorLine},
+}
+
+// jsonFrontmatterRegex matches the start of a JSON frontmatter object, telling
+// it apart from a body starting with a {{< shortcode >}}.
+var jsonFrontmatterRegex = regexp.MustCompile(`^\{\s*["}]`)
+
+// extractFrontmatter separates frontmatter from markdown content read from path.
+// YAML and TOML frontmatter must start on the first line with --- or +++ and
+// end at the first line consisting of the same delimiter. JSON frontmatter is
+// an object starting the content, which may span one or many lines.
+// Content without frontmatter is returned unchanged.
+func extractFrontmatter(path, content string) (frontmatter, string, error) {
+	var fm frontmatter
+	content = strings.TrimPrefix(content, "\ufeff")
+
+	if jsonFrontmatterRegex.MatchString(content) {
+		return extractJSONFrontmatter(path, content)
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	first := strings.TrimSpace(lines[0])
+	var format *frontmatterFormat
+	for i := range frontmatterFormats {
+		if first == frontmatterFormats[i].delim {
+			format = &frontmatterFormats[i]
+			break
+		}
+	}
+	if format == nil {
+		return fm, content, nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == format.delim {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return fm, content, fmt.Errorf("%s:1: %s frontmatter is not closed with %q", path, format.name, format.delim)
+	}
+
+	data := []byte(strings.Join(lines[1:end], ""))
+	if err := format.decode(data, &fm); err != nil {
+		line, msg := format.errorLine(data, err)
+		return fm, content, fmt.Errorf("%s:%d: invalid %s frontmatter: %s", path, max(line, 0)+1, format.name, msg)
+	}
+
+	return fm, strings.TrimSpace(strings.Join(lines[end+1:], "")), nil
+}
+
+// extractJSONFrontmatter decodes the JSON object starting content and
+// returns the rest as the body. The decoder finds the end of the object, so
+// nested objects and braces inside strings need no special care.
+func extractJSONFrontmatter(path, content string) (frontmatter, string, error) {
+	var fm frontmatter
+	dec := json.NewDecoder(strings.NewReader(content))
+	if err := dec.Decode(&fm); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return fm, content, fmt.Errorf("%s:1: JSON frontmatter is not closed with %q", path, "}")
+		}
+		line, msg := jsonErrorLine([]byte(content), err)
+		return fm, content, fmt.Errorf("%s:%d: invalid JSON frontmatter: %s", path, max(line, 1), msg)
+	}
+	return fm, strings.TrimSpace(content[dec.InputOffset():]), nil
+}
+
+// frontmatterKeyRegex matches a key of YAML, TOML or JSON frontmatter.
+const frontmatterKeyRegex = `(?m)(?:^|[{,])[ \t]*"?%s"?[ \t]*[:=]`
+
+// frontmatterKeyLine returns the 1-based line of content holding the
+// frontmatter key, or 1 when it is not found.
+func frontmatterKeyLine(content, key string) int {
+	re := regexp.MustCompile(fmt.Sprintf(frontmatterKeyRegex, regexp.QuoteMeta(key)))
+	loc := re.FindStringIndex(content)
+	if loc == nil {
+		return 1
+	}
+	return strings.Count(content[:loc[0]], "\n") + 1
+}
+
+// StripFrontmatter returns the markdown body of content read from path,
+// without its frontmatter block.
+func StripFrontmatter(path, content string) (string, error) {
+	_, body, err := extractFrontmatter(path, content)
+	return body, err
+}
+
+// decodeTOML decodes a TOML document into v.
+func decodeTOML(data []byte, v any) error {
+	_, err := toml.Decode(string(data), v)
+	return err
+}
+
+// yamlLineRegex matches the line number in yaml.v3 error messages.
+var yamlLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlErrorLine extracts the line number from a yaml.v3 error.
+func yamlErrorLine(data []byte, err error) (int, string) {
+	msg := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0]
+	}
+	if m := yamlLineRegex.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line, m[2]
+	}
+	return 0, strings.TrimPrefix(msg, "yaml: ")
+}
+
+// tomlLineRegex matches the line number in TOML decode error messages.
+var tomlLineRegex = regexp.MustCompile(`^toml: line (\d+)(?: \(last key "[^"]*"\))?: (.*)$`)
+
+// tomlErrorLine extracts the line number from a TOML parse or decode error.
+func tomlErrorLine(data []byte, err error) (int, string) {
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Position.Line, parseErr.Message
+	}
+	if m := tomlLineRegex.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line, m[2]
+	}
+	return 0, strings.TrimPrefix(err.Error(), "toml: ")
+}
+
+// jsonErrorLine converts the byte offset of a JSON error to a line number.
+func jsonErrorLine(data []byte, err error) (int, string) {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return 0, err.Error()
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1, strings.TrimPrefix(err.Error(), "json: ")
+}
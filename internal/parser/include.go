@@ -0,0 +1,172 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// partialsDir is the content subdirectory holding includable markdown.
+const partialsDir = "_partials"
+
+// includeDirectiveRegex matches include and code directives on their own line:
+//
+//	{{< include "disclaimer.md" >}}
+//	{{< code "../cmd/ssg/main.go" 10-25 >}}
+var includeDirectiveRegex = regexp.MustCompile(`^ {0,3}\{\{<\s*(include|code)\s+"([^"]+)"(?:\s+(\d+)-(\d+))?\s*>\}\}\s*$`)
+
+// fenceRegex matches the opening or closing line of a fenced code block.
+var fenceRegex = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+
+// codeLanguages maps file extensions to fenced code block languages where
+// they differ from the extension itself.
+var codeLanguages = map[string]string{
+	"js":  "javascript",
+	"ts":  "typescript",
+	"py":  "python",
+	"rb":  "ruby",
+	"rs":  "rust",
+	"sh":  "bash",
+	"yml": "yaml",
+	"md":  "markdown",
+}
+
+// includer expands include and code directives for a content directory.
+type includer struct {
+	contentDir string
+	displayDir string
+	root       string
+}
+
+// expandIncludes replaces include and code directives in the markdown body of
+// the file at path. Partials are read from _partials in contentDir, code paths
+// are relative to contentDir, and both must stay inside the project root (the
+// parent of contentDir). Directives inside fenced code blocks are left alone.
+func expandIncludes(body, path, contentDir string) (string, error) {
+	abs, err := filepath.Abs(contentDir)
+	if err != nil {
+		return "", err
+	}
+	root, err := filepath.EvalSymlinks(filepath.Dir(abs))
+	if err != nil {
+		return "", err
+	}
+	inc := &includer{contentDir: abs, displayDir: contentDir, root: root}
+	return inc.expand(body, path, []string{filepath.ToSlash(path)})
+}
+
+// expand expands the directives in body, which was read from file.
+// chain lists the files being included, outermost first, to detect cycles.
+func (inc *includer) expand(body, file string, chain []string) (string, error) {
+	lines := strings.Split(body, "\n")
+	var fence string
+	for i, line := range lines {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch {
+			case fence == "":
+				fence = m[1]
+			case m[1][0] == fence[0] && len(m[1]) >= len(fence):
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		m := includeDirectiveRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		var expanded string
+		var err error
+		if m[1] == "include" {
+			expanded, err = inc.include(m[2], chain)
+		} else {
+			expanded, err = inc.code(m[2], m[3], m[4])
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s:%d: %w", file, i+1, err)
+		}
+		lines[i] = expanded
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// include returns the expanded body of the named partial.
+func (inc *includer) include(name string, chain []string) (string, error) {
+	path, err := inc.resolve(filepath.Join(inc.contentDir, partialsDir), name)
+	if err != nil {
+		return "", fmt.Errorf("include %q: %w", name, err)
+	}
+	display := filepath.ToSlash(filepath.Join(inc.displayDir, partialsDir, name))
+	for _, c := range chain {
+		if c == display {
+			return "", fmt.Errorf("include cycle: %s", strings.Join(append(chain, display), " -> "))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("include %q: %w", name, err)
+	}
+	_, body, err := extractFrontmatter(display, string(data))
+	if err != nil {
+		return "", err
+	}
+	return inc.expand(body, display, append(chain[:len(chain):len(chain)], display))
+}
+
+// code returns lines start to end (1-based, inclusive) of the source file at
+// name as a fenced code block. Without a range the whole file is embedded.
+func (inc *includer) code(name, start, end string) (string, error) {
+	path, err := inc.resolve(inc.contentDir, name)
+	if err != nil {
+		return "", fmt.Errorf("code %q: %w", name, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("code %q: %w", name, err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if start != "" {
+		from, _ := strconv.Atoi(start)
+		to, _ := strconv.Atoi(end)
+		if from < 1 || to < from || to > len(lines) {
+			return "", fmt.Errorf("code %q: line range %s-%s outside 1-%d", name, start, end, len(lines))
+		}
+		lines = lines[from-1 : to]
+	}
+	source := strings.Join(lines, "\n")
+
+	fence := "```"
+	for strings.Contains(source, fence) {
+		fence += "`"
+	}
+	lang := strings.TrimPrefix(filepath.Ext(name), ".")
+	if alias, ok := codeLanguages[lang]; ok {
+		lang = alias
+	}
+	return fence + lang + "\n" + source + "\n" + fence, nil
+}
+
+// resolve joins name to dir and verifies the result, with symlinks
+// evaluated, stays inside the project root.
+func (inc *includer) resolve(dir, name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("path must be relative")
+	}
+	path, err := filepath.EvalSymlinks(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(inc.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path is outside the project root")
+	}
+	return path, nil
+}
@@ -0,0 +1,256 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jeroendee/ssg/internal/mathml"
+	"github.com/yuin/goldmark/ast"
+	gmparser "github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Node kinds for math.
+var (
+	kindMathInline = ast.NewNodeKind("MathInline")
+	kindMathBlock  = ast.NewNodeKind("MathBlock")
+)
+
+// mathInline is an inline $...$ (or $$...$$) expression.
+type mathInline struct {
+	ast.BaseInline
+	Tex     []byte
+	Display bool
+	warning string // Set when rendered as text
+}
+
+// Kind returns the inline math node kind.
+func (n *mathInline) Kind() ast.NodeKind {
+	return kindMathInline
+}
+
+// Dump dumps the inline math node for debugging.
+func (n *mathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Tex": string(n.Tex)}, nil)
+}
+
+// mathBlock is a display equation delimited by $$ lines.
+type mathBlock struct {
+	ast.BaseBlock
+	warning string // Set when rendered as text
+}
+
+// Kind returns the math block node kind.
+func (n *mathBlock) Kind() ast.NodeKind {
+	return kindMathBlock
+}
+
+// IsRaw reports that the block content is not parsed as markdown.
+func (n *mathBlock) IsRaw() bool {
+	return true
+}
+
+// Dump dumps the math block node for debugging.
+func (n *mathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// mathInlineParser parses $...$ and $$...$$ within a line.
+// Like Pandoc, an opening $ must be followed by a non-space and the closing $
+// must follow a non-space and not precede a digit, so "$5 and $10" stays text.
+type mathInlineParser struct{}
+
+// Trigger returns the character that starts inline math.
+func (p *mathInlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Parse parses inline math at the current position.
+func (p *mathInlineParser) Parse(parent ast.Node, block text.Reader, pc gmparser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	// The second $ of a rejected $$ is not an opening delimiter of its own.
+	if block.PrecendingCharacter() == '$' {
+		return nil
+	}
+
+	if bytes.HasPrefix(line, []byte("$$")) {
+		end := bytes.Index(line[2:], []byte("$$"))
+		if end < 1 || util.IsSpace(line[2]) || util.IsSpace(line[1+end]) || 2+end+2 < len(line) && isDigit(line[2+end+2]) {
+			return nil
+		}
+		block.Advance(end + 4)
+		return &mathInline{Tex: append([]byte(nil), line[2:2+end]...), Display: true}
+	}
+
+	if len(line) < 2 || util.IsSpace(line[1]) {
+		return nil
+	}
+	for i := 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '$':
+			if util.IsSpace(line[i-1]) || i+1 < len(line) && isDigit(line[i+1]) {
+				return nil
+			}
+			block.Advance(i + 1)
+			return &mathInline{Tex: append([]byte(nil), line[1:i]...)}
+		}
+	}
+	return nil
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// mathBlockParser parses display math between $$ delimiter lines.
+type mathBlockParser struct{}
+
+// Trigger returns the character that starts a math block.
+func (b *mathBlockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Open starts a math block at a line beginning with $$.
+func (b *mathBlockParser) Open(parent ast.Node, reader text.Reader, pc gmparser.Context) (ast.Node, gmparser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], []byte("$$")) {
+		return nil, gmparser.NoChildren
+	}
+	start := pos + 2
+	rest := line[start:]
+	node := &mathBlock{}
+
+	// Single-line form: $$ x^2 $$
+	if end := bytes.Index(rest, []byte("$$")); end >= 0 {
+		if !util.IsBlank(rest[end+2:]) {
+			return nil, gmparser.NoChildren
+		}
+		node.Lines().Append(text.NewSegment(segment.Start+start, segment.Start+start+end))
+		reader.AdvanceToEOL()
+		return node, gmparser.Close
+	}
+
+	if !util.IsBlank(rest) {
+		node.Lines().Append(text.NewSegment(segment.Start+start, segment.Stop))
+	}
+	reader.AdvanceToEOL()
+	return node, gmparser.NoChildren
+}
+
+// Continue adds lines to the math block until the closing $$.
+func (b *mathBlockParser) Continue(node ast.Node, reader text.Reader, pc gmparser.Context) gmparser.State {
+	line, segment := reader.PeekLine()
+	if line == nil {
+		return gmparser.Close
+	}
+	trimmed := util.TrimRightSpace(line)
+	if bytes.HasSuffix(trimmed, []byte("$$")) {
+		content := len(trimmed) - 2
+		if !util.IsBlank(trimmed[:content]) {
+			node.Lines().Append(text.NewSegment(segment.Start, segment.Start+content))
+		}
+		reader.AdvanceToEOL()
+		return gmparser.Close
+	}
+	node.Lines().Append(segment)
+	reader.AdvanceToEOL()
+	return gmparser.Continue | gmparser.NoChildren
+}
+
+// Close finalizes the math block.
+func (b *mathBlockParser) Close(node ast.Node, reader text.Reader, pc gmparser.Context) {}
+
+// CanInterruptParagraph reports that $$ may start a block directly after a paragraph.
+func (b *mathBlockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine reports that indented lines do not start a math block.
+func (b *mathBlockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// mathRenderer renders math nodes as MathML.
+type mathRenderer struct {
+	gmhtml.Config
+}
+
+// newMathRenderer returns a new renderer for math nodes.
+func newMathRenderer(opts ...gmhtml.Option) renderer.NodeRenderer {
+	r := &mathRenderer{
+		Config: gmhtml.NewConfig(),
+	}
+	for _, opt := range opts {
+		opt.SetHTMLOption(&r.Config)
+	}
+	return r
+}
+
+// RegisterFuncs registers the math renderer functions.
+func (r *mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMathInline, r.renderMathInline)
+	reg.Register(kindMathBlock, r.renderMathBlock)
+}
+
+// renderMathInline renders inline math.
+func (r *mathRenderer) renderMathInline(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*mathInline)
+	delim := "$"
+	if n.Display {
+		delim = "$$"
+	}
+	out, warning := convertMath(string(n.Tex), n.Display, delim)
+	if warning == "" {
+		_, _ = w.WriteString(out)
+	} else {
+		n.warning = warning
+		_, _ = w.Write(util.EscapeHTML([]byte(out)))
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+// renderMathBlock renders a display equation.
+func (r *mathRenderer) renderMathBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	var tex bytes.Buffer
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		tex.Write(segment.Value(source))
+	}
+	out, warning := convertMath(tex.String(), true, "$$")
+	if warning == "" {
+		_, _ = w.WriteString(out)
+	} else {
+		node.(*mathBlock).warning = warning
+		_, _ = w.WriteString("<p>")
+		_, _ = w.Write(util.EscapeHTML([]byte(out)))
+		_, _ = w.WriteString("</p>")
+	}
+	_ = w.WriteByte('\n')
+	return ast.WalkSkipChildren, nil
+}
+
+// convertMath converts tex to MathML. When tex does not convert, it returns
+// the source between its delimiters as text with a warning, so a stray
+// dollar sign never fails the build.
+func convertMath(tex string, display bool, delim string) (out, warning string) {
+	out, err := mathml.Convert(tex, display)
+	if err != nil {
+		source := delim + tex + delim
+		return source, fmt.Sprintf("math %q kept as text: %v", source, err)
+	}
+	return out, ""
+}
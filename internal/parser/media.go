@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jeroendee/ssg/internal/model"
+)
+
+// mediaTypes maps media file extensions to MIME types, per kind.
+var mediaTypes = map[string]map[string]string{
+	"audio": {
+		".mp3":  "audio/mpeg",
+		".m4a":  "audio/x-m4a",
+		".aac":  "audio/aac",
+		".ogg":  "audio/ogg",
+		".oga":  "audio/ogg",
+		".opus": "audio/opus",
+		".wav":  "audio/wav",
+		".flac": "audio/flac",
+	},
+	"video": {
+		".mp4":  "video/mp4",
+		".m4v":  "video/x-m4v",
+		".mov":  "video/quicktime",
+		".webm": "video/webm",
+		".ogv":  "video/ogg",
+	},
+}
+
+// parseMedia reads an audio or video asset referenced from frontmatter.
+// The asset path must be relative to the post, under assets/, like image
+// references. The file size and MIME type come from the file on disk.
+func parseMedia(kind, asset, baseDir string) (*model.Media, error) {
+	if asset == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(asset, "assets/") {
+		return nil, fmt.Errorf("%s %q must be an asset path like assets/file", kind, asset)
+	}
+	mimeType, ok := mediaTypes[kind][strings.ToLower(filepath.Ext(asset))]
+	if !ok {
+		return nil, fmt.Errorf("%s %q has an unsupported file type", kind, asset)
+	}
+	info, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(asset)))
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", kind, asset, err)
+	}
+	return &model.Media{
+		Path:   asset,
+		Type:   mimeType,
+		Length: info.Size(),
+	}, nil
+}
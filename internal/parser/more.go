@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// moreMarker is the comment that ends the excerpt of a post.
+const moreMarker = "<!--more-->"
+
+// kindMore is the node kind for the excerpt marker.
+var kindMore = ast.NewNodeKind("More")
+
+// more is a block node for a <!--more--> line, rendered as the marker itself
+// rather than as omitted raw HTML.
+type more struct {
+	ast.BaseBlock
+}
+
+// Kind returns the excerpt marker node kind.
+func (n *more) Kind() ast.NodeKind {
+	return kindMore
+}
+
+// Dump dumps the excerpt marker node for debugging.
+func (n *more) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// markMore replaces the first top-level HTML block holding only the
+// <!--more--> marker with a more node, and reports whether it found one.
+func markMore(doc ast.Node, source []byte) bool {
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		block, ok := n.(*ast.HTMLBlock)
+		if !ok {
+			continue
+		}
+		var raw bytes.Buffer
+		lines := block.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			segment := lines.At(i)
+			raw.Write(segment.Value(source))
+		}
+		if block.HasClosure() {
+			raw.Write(block.ClosureLine.Value(source))
+		}
+		if string(bytes.TrimSpace(raw.Bytes())) == moreMarker {
+			doc.ReplaceChild(doc, block, &more{})
+			return true
+		}
+	}
+	return false
+}
+
+// moreRenderer renders the excerpt marker.
+type moreRenderer struct {
+	gmhtml.Config
+}
+
+// newMoreRenderer returns a new renderer for excerpt marker nodes.
+func newMoreRenderer(opts ...gmhtml.Option) renderer.NodeRenderer {
+	r := &moreRenderer{
+		Config: gmhtml.NewConfig(),
+	}
+	for _, opt := range opts {
+		opt.SetHTMLOption(&r.Config)
+	}
+	return r
+}
+
+// RegisterFuncs registers the excerpt marker renderer function.
+func (r *moreRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMore, r.renderMore)
+}
+
+// renderMore writes the marker as an HTML comment.
+func (r *moreRenderer) renderMore(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(moreMarker + "\n")
+	}
+	return ast.WalkContinue, nil
+}
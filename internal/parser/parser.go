@@ -2,89 +2,169 @@ package parser
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jeroendee/ssg/internal/model"
-	"github.com/jeroendee/ssg/internal/wordcount"
+	"github.com/jeroendee/ssg/internal/textcount"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	gmparser "github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/util"
-	"gopkg.in/yaml.v3"
 )
 
-// frontmatter holds metadata extracted from markdown files.
-type frontmatter struct {
-	Title   string `yaml:"title"`
-	Summary string `yaml:"summary"`
-	Date    string `yaml:"date"`
+// Options configures optional markdown rendering features.
+type Options struct {
+	// Figures renders standalone images as <figure> elements captioned by the
+	// image title, and adds lazy loading and intrinsic dimensions to images.
+	Figures bool
+	// WikiLinks parses [[target]] and [[target|label]] links. They render with
+	// placeholder hrefs that must be resolved with [ResolveWikiLinks].
+	WikiLinks bool
+	// Math renders $...$ and $$...$$ LaTeX as MathML. Math that does not
+	// convert is kept as text and reported in the page's Warnings.
+	Math bool
+	// AssetsDir is where root-relative image paths (e.g. /logo.png) are read
+	// from when determining image dimensions.
+	AssetsDir string
+	// ContentDir enables include and code directives. Partials are read from
+	// its _partials subdirectory and source paths are relative to it.
+	ContentDir string
+	// WordsPerMinute is the reading speed of post reading time estimates;
+	// textcount.DefaultWordsPerMinute when zero.
+	WordsPerMinute int
+}
+
+// features returns opts with only the fields that change the Goldmark setup.
+func (opts Options) features() Options {
+	return Options{
+		Figures:   opts.Figures,
+		WikiLinks: opts.WikiLinks,
+		Math:      opts.Math,
+	}
 }
 
 // md is the configured Goldmark instance with auto heading IDs and anchor links.
-var md = goldmark.New(
-	goldmark.WithExtensions(extension.Table),
-	goldmark.WithParserOptions(
+var md = newMarkdown(Options{})
+
+// converters caches Goldmark instances per feature set.
+var (
+	convertersMu sync.Mutex
+	converters   = map[Options]goldmark.Markdown{{}: md}
+)
+
+// converterFor returns the Goldmark instance for the features enabled in opts.
+func converterFor(opts Options) goldmark.Markdown {
+	key := opts.features()
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	if c, ok := converters[key]; ok {
+		return c
+	}
+	c := newMarkdown(key)
+	converters[key] = c
+	return c
+}
+
+// newMarkdown creates a Goldmark instance with the features enabled in opts.
+func newMarkdown(opts Options) goldmark.Markdown {
+	parserOpts := []gmparser.Option{
 		gmparser.WithAutoHeadingID(),
-	),
-	goldmark.WithRendererOptions(
-		renderer.WithNodeRenderers(
-			util.Prioritized(newAnchorHeadingRenderer(), 100),
+	}
+	nodeRenderers := []util.PrioritizedValue{
+		util.Prioritized(newAnchorHeadingRenderer(), 100),
+		util.Prioritized(newMoreRenderer(), 100),
+	}
+	if opts.Math {
+		parserOpts = append(parserOpts,
+			gmparser.WithBlockParsers(util.Prioritized(&mathBlockParser{}, 750)),
+			gmparser.WithInlineParsers(util.Prioritized(&mathInlineParser{}, 150)),
+		)
+		nodeRenderers = append(nodeRenderers, util.Prioritized(newMathRenderer(), 100))
+	}
+	if opts.WikiLinks {
+		parserOpts = append(parserOpts, gmparser.WithInlineParsers(
+			util.Prioritized(&wikiLinkParser{}, 199),
+		))
+		nodeRenderers = append(nodeRenderers, util.Prioritized(newWikiLinkRenderer(), 100))
+	}
+	if opts.Figures {
+		parserOpts = append(parserOpts, gmparser.WithASTTransformers(
+			util.Prioritized(&figureTransformer{}, 100),
+		))
+		nodeRenderers = append(nodeRenderers, util.Prioritized(newFigureRenderer(), 100))
+	}
+	return goldmark.New(
+		goldmark.WithExtensions(extension.Table),
+		goldmark.WithParserOptions(parserOpts...),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(nodeRenderers...),
 		),
-	),
-)
+	)
+}
 
 // MarkdownToHTMLWithError converts markdown content to HTML and returns any conversion error.
+// It uses the zero [Options], so math, figures and wiki links are left as
+// written; content pages are converted with [ParsePageWithOptions] instead.
 func MarkdownToHTMLWithError(markdown string) (string, error) {
-	var buf bytes.Buffer
-	if err := md.Convert([]byte(markdown), &buf); err != nil {
-		return "", fmt.Errorf("markdown conversion failed: %w", err)
-	}
-	return buf.String(), nil
+	return markdownToHTML(markdown, "", Options{})
 }
 
-// extractFrontmatter separates YAML frontmatter from markdown content.
-func extractFrontmatter(content string) (frontmatter, string, error) {
-	var fm frontmatter
-	if !strings.HasPrefix(content, "---") {
-		return fm, content, nil
-	}
+// markdownToHTML converts markdown to HTML with the given options.
+// baseDir is the directory of the source file, used to resolve relative image paths.
+func markdownToHTML(markdown, baseDir string, opts Options) (string, error) {
+	converter := converterFor(opts)
 
-	parts := strings.SplitN(content, "---", 3)
-	if len(parts) < 3 {
-		return fm, content, nil
-	}
+	ctx := gmparser.NewContext()
+	ctx.Set(baseDirKey, baseDir)
+	ctx.Set(assetsDirKey, opts.AssetsDir)
 
-	if err := yaml.Unmarshal([]byte(parts[1]), &fm); err != nil {
-		return fm, content, err
+	var buf bytes.Buffer
+	if err := converter.Convert([]byte(markdown), &buf, gmparser.WithContext(ctx)); err != nil {
+		return "", fmt.Errorf("markdown conversion failed: %w", err)
 	}
-
-	return fm, strings.TrimSpace(parts[2]), nil
+	return buf.String(), nil
 }
 
 // ParsePage reads a markdown file and returns a Page.
 func ParsePage(path string) (*model.Page, error) {
+	return ParsePageWithOptions(path, Options{})
+}
+
+// ParsePageWithOptions reads a markdown file and returns a Page rendered with opts.
+func ParsePageWithOptions(path string, opts Options) (*model.Page, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	fm, body, err := extractFrontmatter(string(data))
+	fm, body, err := extractFrontmatter(path, string(data))
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.ContentDir != "" {
+		body, err = expandIncludes(body, path, opts.ContentDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	slug := strings.TrimSuffix(filepath.Base(path), ".md")
 	if slug == "home" {
 		slug = ""
 	}
 
-	html, err := MarkdownToHTMLWithError(body)
+	a, err := analyze(body, filepath.Dir(path), opts)
 	if err != nil {
 		return nil, fmt.Errorf("parsing %s: %w", path, err)
 	}
@@ -94,35 +174,51 @@ func ParsePage(path string) (*model.Page, error) {
 		pagePath = "/"
 	}
 
-	dateAnchors := ExtractDateAnchors(body)
-	currentMonth, archivedMonths := GroupDatesByMonth(dateAnchors)
+	currentMonth, archivedMonths := GroupDatesByMonth(a.DateAnchors)
 	archivedYears := GroupMonthsByYear(archivedMonths)
 
 	return &model.Page{
 		Title:             fm.Title,
 		Slug:              slug,
-		Content:           html,
+		Content:           a.HTML,
+		Markdown:          body,
+		Text:              a.Text,
+		Headings:          a.Headings,
 		Path:              pagePath,
-		DateAnchors:       dateAnchors,
+		DateAnchors:       a.DateAnchors,
 		CurrentMonthDates: currentMonth,
 		ArchivedYears:     archivedYears,
+		Lang:              fm.Lang,
+		Description:       fm.Description,
+		Canonical:         fm.Canonical,
+		NoIndex:           fm.NoIndex,
+		Image:             fm.Image,
+		Draft:             fm.Draft,
+		Warnings:          fileWarnings(path, a.Warnings),
 	}, nil
 }
 
+// fileWarnings prefixes warnings with the path of the file they concern.
+func fileWarnings(path string, warnings []string) []string {
+	var prefixed []string
+	for _, warning := range warnings {
+		prefixed = append(prefixed, path+": "+warning)
+	}
+	return prefixed
+}
+
 var dateFilenameRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)\.md$`)
-var dateAnchorRegex = regexp.MustCompile(`(?m)^#{1,6} \*(\d{4}-\d{2}-\d{2})\*`)
 
 // ExtractDateAnchors finds date headings (h1-h6) in italic format from markdown.
+// Headings with a time, like ## *2026-01-27 14:30*, yield anchors such as
+// 2026-01-27-1430, matching the heading IDs in the rendered HTML. Use the
+// DateAnchors of [Analyze] when the markdown is converted anyway.
 func ExtractDateAnchors(markdown string) []string {
-	matches := dateAnchorRegex.FindAllStringSubmatch(markdown, -1)
-	if matches == nil {
+	a, err := Analyze(markdown)
+	if err != nil {
 		return []string{}
 	}
-	dates := make([]string, len(matches))
-	for i, m := range matches {
-		dates[i] = m[1]
-	}
-	return dates
+	return a.DateAnchors
 }
 
 // GroupDatesByMonth separates date anchors into current month and archived months.
@@ -142,7 +238,7 @@ func GroupDatesByMonth(dates []string) (currentMonth []string, archived []model.
 	var validDates []yearMonth
 
 	for _, d := range dates {
-		t, err := time.Parse("2006-01-02", d)
+		t, err := ParseDateFromAnchor(d)
 		if err != nil {
 			continue
 		}
@@ -235,16 +331,17 @@ func GroupMonthsByYear(months []model.MonthGroup) []model.YearGroup {
 	return result
 }
 
-var assetRefRegex = regexp.MustCompile(`!\[.*?\]\((assets/[^)]+)\)`)
-
-// dateHeaderRegex matches HTML headings with date anchor IDs (YYYY-MM-DD format).
+// dateHeaderRegex matches HTML headings with date anchor IDs (YYYY-MM-DD or
+// YYYY-MM-DD-HHMM format).
 // Handles headings like: <h2 id="2026-01-27"><a href="#2026-01-27">January 27, 2026</a></h2>
-var dateHeaderRegex = regexp.MustCompile(`<h[1-6] id="(\d{4}-\d{2}-\d{2})">.*?</h[1-6]>`)
+var dateHeaderRegex = regexp.MustCompile(`<h[1-6] id="(\d{4}-\d{2}-\d{2}(?:-\d{4})?)">.*?</h[1-6]>`)
 
 // FeedDateSection represents a date-anchored section extracted from HTML for feed generation.
 type FeedDateSection struct {
 	Anchor  string
 	Content string
+	Title   string // First heading or first sentence of the content
+	Words   int    // Number of words in the content, excluding code blocks
 }
 
 // ExtractFeedDateSections finds date-anchored sections in HTML content.
@@ -286,45 +383,148 @@ func ExtractFeedDateSections(html string) []FeedDateSection {
 		sections = append(sections, FeedDateSection{
 			Anchor:  anchor,
 			Content: content,
+			Title:   sectionTitle(content),
+			Words:   sectionWords(content),
 		})
 	}
 
 	return sections
 }
 
-// ParseDateFromAnchor parses a date string in YYYY-MM-DD format to time.Time.
+// ArchivedMonth holds the date sections of a month moved to an archive page.
+type ArchivedMonth struct {
+	Date    time.Time // First day of the month
+	Anchors []string
+	Content string
+}
+
+// SplitArchiveMonths separates rendered page HTML into the content kept on
+// the page, which is everything before the first date heading plus the
+// sections of the most recent month, and the sections of each older month,
+// newest month first. Each section runs from its date heading to the next.
+// Sections with invalid dates stay on the page.
+func SplitArchiveMonths(html string) (string, []ArchivedMonth) {
+	matches := dateHeaderRegex.FindAllStringSubmatchIndex(html, -1)
+	if matches == nil {
+		return html, nil
+	}
+
+	type section struct {
+		anchor string
+		month  time.Time
+		html   string
+	}
+	sections := make([]section, len(matches))
+	var current time.Time
+	for i, match := range matches {
+		end := len(html)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		anchor := html[match[2]:match[3]]
+		sections[i] = section{anchor: anchor, html: html[match[0]:end]}
+		if date, err := ParseDateFromAnchor(anchor); err == nil {
+			sections[i].month = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+			if sections[i].month.After(current) {
+				current = sections[i].month
+			}
+		}
+	}
+
+	var kept strings.Builder
+	kept.WriteString(html[:matches[0][0]])
+	var archived []ArchivedMonth
+	index := make(map[time.Time]int)
+	for _, s := range sections {
+		if s.month.IsZero() || s.month.Equal(current) {
+			kept.WriteString(s.html)
+			continue
+		}
+		i, ok := index[s.month]
+		if !ok {
+			i = len(archived)
+			index[s.month] = i
+			archived = append(archived, ArchivedMonth{Date: s.month})
+		}
+		archived[i].Anchors = append(archived[i].Anchors, s.anchor)
+		archived[i].Content += s.html
+	}
+
+	sort.Slice(archived, func(i, j int) bool {
+		return archived[i].Date.After(archived[j].Date)
+	})
+	return kept.String(), archived
+}
+
+// TrimArchivedMarkdown returns markdown without the date sections whose
+// anchors are keys of archived, the markdown of the content kept on the page
+// by [SplitArchiveMonths]. Each section runs from its date heading to the next.
+func TrimArchivedMarkdown(markdown string, archived map[string]string) string {
+	a, err := analyze(markdown, "", Options{})
+	if err != nil || len(a.dateOffsets) == 0 {
+		return markdown
+	}
+
+	var kept strings.Builder
+	kept.WriteString(markdown[:a.dateOffsets[0]])
+	for i, start := range a.dateOffsets {
+		end := len(markdown)
+		if i+1 < len(a.dateOffsets) {
+			end = a.dateOffsets[i+1]
+		}
+		if _, ok := archived[a.DateAnchors[i]]; !ok {
+			kept.WriteString(markdown[start:end])
+		}
+	}
+	return kept.String()
+}
+
+// ParseDateFromAnchor parses a date anchor in YYYY-MM-DD or YYYY-MM-DD-HHMM
+// format to time.Time.
 func ParseDateFromAnchor(anchor string) (time.Time, error) {
 	if anchor == "" {
 		return time.Time{}, fmt.Errorf("empty anchor")
 	}
+	if len(anchor) > len("2006-01-02") {
+		return time.Parse("2006-01-02-1504", anchor)
+	}
 	return time.Parse("2006-01-02", anchor)
 }
 
 // ExtractAssetReferences finds all asset references in markdown content.
+// Use the Assets of [Analyze] when the markdown is converted anyway.
 func ExtractAssetReferences(markdown string) []string {
-	matches := assetRefRegex.FindAllStringSubmatch(markdown, -1)
-	if matches == nil {
+	a, err := Analyze(markdown)
+	if err != nil {
 		return []string{}
 	}
-	assets := make([]string, len(matches))
-	for i, m := range matches {
-		assets[i] = m[1]
-	}
-	return assets
+	return a.Assets
 }
 
 // ParsePost reads a markdown file and returns a Post.
 func ParsePost(path string) (*model.Post, error) {
+	return ParsePostWithOptions(path, Options{})
+}
+
+// ParsePostWithOptions reads a markdown file and returns a Post rendered with opts.
+func ParsePostWithOptions(path string, opts Options) (*model.Post, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	fm, body, err := extractFrontmatter(string(data))
+	fm, body, err := extractFrontmatter(path, string(data))
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.ContentDir != "" {
+		body, err = expandIncludes(body, path, opts.ContentDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	filename := filepath.Base(path)
 	var postDate time.Time
 	slug := strings.TrimSuffix(filename, ".md")
@@ -340,27 +540,65 @@ func ParsePost(path string) (*model.Post, error) {
 
 	// Frontmatter date overrides filename date
 	if fm.Date != "" {
-		postDate, err = time.Parse("2006-01-02", fm.Date)
+		postDate, err = time.Parse("2006-01-02", string(fm.Date))
 		if err != nil {
-			return nil, fmt.Errorf("invalid date in frontmatter: %w", err)
+			return nil, fmt.Errorf("%s:%d: invalid date in frontmatter: %w", path, frontmatterKeyLine(string(data), "date"), err)
 		}
 	}
 
-	html, err := MarkdownToHTMLWithError(body)
+	a, err := analyze(body, filepath.Dir(path), opts)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	audio, err := parseMedia("audio", fm.Audio, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	video, err := parseMedia("video", fm.Video, filepath.Dir(path))
 	if err != nil {
 		return nil, fmt.Errorf("parsing %s: %w", path, err)
 	}
 
+	assets := a.Assets
+	for _, media := range []*model.Media{audio, video} {
+		if media != nil {
+			assets = append(assets, media.Path)
+		}
+	}
+	if strings.HasPrefix(fm.Image, "assets/") && !slices.Contains(assets, fm.Image) {
+		assets = append(assets, fm.Image)
+	}
+
 	return &model.Post{
 		Page: model.Page{
-			Title:   fm.Title,
-			Slug:    slug,
-			Content: html,
-			Path:    "/blog/" + slug + "/",
+			Title:       fm.Title,
+			Slug:        slug,
+			Content:     a.HTML,
+			Markdown:    body,
+			Text:        a.Text,
+			Headings:    a.Headings,
+			Path:        "/blog/" + slug + "/",
+			Lang:        fm.Lang,
+			Description: fm.Description,
+			Canonical:   fm.Canonical,
+			NoIndex:     fm.NoIndex,
+			Image:       fm.Image,
+			Draft:       fm.Draft,
+			Warnings:    fileWarnings(path, a.Warnings),
 		},
-		Date:      postDate,
-		Summary:   fm.Summary,
-		WordCount: wordcount.Count(body),
-		Assets:    ExtractAssetReferences(body),
+		Date:        postDate,
+		Summary:     cmp.Or(fm.Summary, a.Summary),
+		Excerpt:     a.Excerpt,
+		WordCount:   a.Words.Total(),
+		ReadingTime: textcount.ReadingTime(a.Words, opts.WordsPerMinute),
+		Assets:      assets,
+		Author:      fm.Author,
+		Tags:        fm.Tags,
+		GUID:        fm.GUID,
+		Audio:       audio,
+		Video:       video,
+		Duration:    fm.Duration,
+		Episode:     fm.Episode,
 	}, nil
 }
@@ -1,14 +1,19 @@
 package parser_test
 
 import (
+	"fmt"
+	"image"
+	"image/png"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/jeroendee/ssg/internal/model"
 	"github.com/jeroendee/ssg/internal/parser"
+	"github.com/jeroendee/ssg/internal/wordcount"
 )
 
 func TestParseMarkdown(t *testing.T) {
@@ -55,6 +60,34 @@ This is the about page.`
 	}
 }
 
+func TestParsePage_Lang(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	page := filepath.Join(dir, "momenten.md")
+	if err := os.WriteFile(page, []byte("---\ntitle: Momenten\nlang: nl\n---\nHallo."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	post := filepath.Join(dir, "2026-01-27-hallo.md")
+	if err := os.WriteFile(post, []byte("+++\ntitle = \"Hallo\"\nlang = \"nl-BE\"\n+++\nHallo."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := parser.ParsePage(page)
+	if err != nil {
+		t.Fatalf("ParsePage() error = %v", err)
+	}
+	if p.Lang != "nl" {
+		t.Errorf("page Lang = %q, want %q", p.Lang, "nl")
+	}
+	ps, err := parser.ParsePost(post)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	if ps.Lang != "nl-BE" {
+		t.Errorf("post Lang = %q, want %q", ps.Lang, "nl-BE")
+	}
+}
+
 func TestParsePost_DateFromFrontmatter(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -147,6 +180,35 @@ Hello! My name is Jeroen.`
 	}
 }
 
+func TestParsePost_ReadingTime(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "test-post.md")
+	content := "---\ntitle: \"Test Post\"\n---\n" + strings.Repeat("word ", 500) + strings.Repeat("字", 500)
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	post, err := parser.ParsePost(file)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	if post.WordCount != 1000 {
+		t.Errorf("WordCount = %d, want 1000", post.WordCount)
+	}
+	if post.ReadingTime != 4 {
+		t.Errorf("ReadingTime = %d, want 4 at the default speed", post.ReadingTime)
+	}
+
+	post, err = parser.ParsePostWithOptions(file, parser.Options{WordsPerMinute: 100})
+	if err != nil {
+		t.Fatalf("ParsePostWithOptions() error = %v", err)
+	}
+	if post.ReadingTime != 7 {
+		t.Errorf("ReadingTime = %d, want 7 at 100 words per minute", post.ReadingTime)
+	}
+}
+
 func TestMarkdownToHTML_ReturnsError(t *testing.T) {
 	t.Parallel()
 	// Valid markdown should not return error
@@ -160,25 +222,6 @@ func TestMarkdownToHTML_ReturnsError(t *testing.T) {
 	}
 }
 
-func TestParsePost_InvalidFrontmatterDate(t *testing.T) {
-	t.Parallel()
-	dir := t.TempDir()
-	file := filepath.Join(dir, "my-post.md")
-	content := `---
-title: "My Post"
-date: "not-a-date"
----
-# Content`
-	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
-		t.Fatal(err)
-	}
-
-	_, err := parser.ParsePost(file)
-	if err == nil {
-		t.Error("ParsePost() expected error for invalid frontmatter date")
-	}
-}
-
 func TestParsePost_InvalidFilenameDate(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -302,6 +345,11 @@ func TestExtractAssetReferences(t *testing.T) {
 			markdown: "![nested](assets/images/photo.jpg)",
 			want:     []string{"assets/images/photo.jpg"},
 		},
+		{
+			name:     "asset with title",
+			markdown: `![photo](assets/photo.jpg "A caption")`,
+			want:     []string{"assets/photo.jpg"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -321,6 +369,193 @@ func TestExtractAssetReferences(t *testing.T) {
 	}
 }
 
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+	md := "# Notes &amp; more\n\n" +
+		"## *2026-01-27 14:30*\n\n" +
+		"Moved to **Docker**, see <https://example.com>.\n" +
+		"Second line with $x^2$ math.\n\n" +
+		"![a diagram](assets/diagram.png \"Caption\")\n\n" +
+		"```\n## *2025-01-01*\n![code](assets/code.png)\n```\n\n" +
+		"| 名前 | Role |\n| --- | --- |\n| 山田 | Tester |\n"
+
+	a, err := parser.Analyze(md)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	html, err := parser.MarkdownToHTMLWithError(md)
+	if err != nil {
+		t.Fatalf("MarkdownToHTMLWithError() error = %v", err)
+	}
+	if a.HTML != html {
+		t.Errorf("HTML = %q, want %q", a.HTML, html)
+	}
+
+	wantText := "Notes & more\n2026-01-27 14:30\nMoved to Docker, see https://example.com. Second line with $x^2$ math.\n名前\nRole\n山田\nTester"
+	if a.Text != wantText {
+		t.Errorf("Text = %q, want %q", a.Text, wantText)
+	}
+	if want := (wordcount.Counts{Words: 16, CJK: 4}); a.Words != want {
+		t.Errorf("Words = %+v, want %+v", a.Words, want)
+	}
+	if !reflect.DeepEqual(a.Assets, []string{"assets/diagram.png"}) {
+		t.Errorf("Assets = %v, want [assets/diagram.png]", a.Assets)
+	}
+	if !reflect.DeepEqual(a.DateAnchors, []string{"2026-01-27-1430"}) {
+		t.Errorf("DateAnchors = %v, want [2026-01-27-1430]", a.DateAnchors)
+	}
+	wantHeadings := []model.Heading{
+		{Level: 1, ID: "notes-amp-more", Text: "Notes & more"},
+		{Level: 2, ID: "2026-01-27-1430", Text: "2026-01-27 14:30"},
+	}
+	if !reflect.DeepEqual(a.Headings, wantHeadings) {
+		t.Errorf("Headings = %+v, want %+v", a.Headings, wantHeadings)
+	}
+}
+
+func TestAnalyze_SummaryAndExcerpt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		markdown    string
+		wantSummary string
+		wantExcerpt string
+	}{
+		{
+			name:        "first paragraph without markup",
+			markdown:    "# Title\n\n![photo](assets/a.png)\n\nFirst *paragraph* with [a link](/x/) &amp; `code`.\n\nSecond paragraph.",
+			wantSummary: "First paragraph with a link & code.",
+		},
+		{
+			name:        "long paragraph cut at a word boundary",
+			markdown:    strings.Repeat("word ", 40) + "end.",
+			wantSummary: strings.TrimSpace(strings.Repeat("word ", 32)) + "…",
+		},
+		{
+			name:        "more marker",
+			markdown:    "Intro with **bold**.\n\n<!--more-->\n\nThe rest.",
+			wantSummary: "Intro with bold.",
+			wantExcerpt: "<p>Intro with <strong>bold</strong>.</p>",
+		},
+		{
+			name:        "no paragraph",
+			markdown:    "# Only a heading",
+			wantSummary: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a, err := parser.Analyze(tt.markdown)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if a.Summary != tt.wantSummary {
+				t.Errorf("Summary = %q, want %q", a.Summary, tt.wantSummary)
+			}
+			if a.Excerpt != tt.wantExcerpt {
+				t.Errorf("Excerpt = %q, want %q", a.Excerpt, tt.wantExcerpt)
+			}
+			if tt.wantExcerpt != "" && !strings.Contains(a.HTML, "<!--more-->") {
+				t.Error("HTML should keep the more marker")
+			}
+		})
+	}
+}
+
+func TestParsePost_Summary(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	derived := filepath.Join(dir, "derived.md")
+	if err := os.WriteFile(derived, []byte("---\ntitle: Derived\n---\nThe first paragraph.\n\nMore text."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	post, err := parser.ParsePost(derived)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	if post.Summary != "The first paragraph." {
+		t.Errorf("Summary = %q, want the first paragraph", post.Summary)
+	}
+
+	explicit := filepath.Join(dir, "explicit.md")
+	if err := os.WriteFile(explicit, []byte("---\ntitle: Explicit\nsummary: Set by hand\n---\nThe first paragraph.\n\n<!--more-->\n\nMore text."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	post, err = parser.ParsePost(explicit)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	if post.Summary != "Set by hand" {
+		t.Errorf("Summary = %q, want the frontmatter summary", post.Summary)
+	}
+	if post.Excerpt != "<p>The first paragraph.</p>" {
+		t.Errorf("Excerpt = %q, want the text before the more marker", post.Excerpt)
+	}
+}
+
+func TestParsePost_SEOFrontmatter(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "post.md")
+	content := "---\ntitle: Post\ndescription: A description\ncanonical: https://other.example/post/\nnoindex: true\nimage: assets/cover.jpg\n---\nBody with ![x](assets/inline.png)."
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	post, err := parser.ParsePost(file)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	if post.Description != "A description" || post.Canonical != "https://other.example/post/" || !post.NoIndex || post.Image != "assets/cover.jpg" {
+		t.Errorf("SEO fields = %q, %q, %v, %q", post.Description, post.Canonical, post.NoIndex, post.Image)
+	}
+	if !reflect.DeepEqual(post.Assets, []string{"assets/inline.png", "assets/cover.jpg"}) {
+		t.Errorf("Assets = %v, want the inline image and the card image", post.Assets)
+	}
+
+	page, err := parser.ParsePage(file)
+	if err != nil {
+		t.Fatalf("ParsePage() error = %v", err)
+	}
+	if page.Description != "A description" || !page.NoIndex || page.Image != "assets/cover.jpg" {
+		t.Errorf("page SEO fields = %q, %v, %q", page.Description, page.NoIndex, page.Image)
+	}
+}
+
+func TestParsePage_DraftAndMarkdown(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "wip.md")
+	content := "---\ntitle: Work in progress\ndraft: true\n---\n# Heading\n\nBody text.\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := parser.ParsePage(file)
+	if err != nil {
+		t.Fatalf("ParsePage() error = %v", err)
+	}
+	if !page.Draft {
+		t.Error("Draft = false, want true")
+	}
+	if want := "# Heading\n\nBody text."; page.Markdown != want {
+		t.Errorf("Markdown = %q, want %q", page.Markdown, want)
+	}
+
+	post, err := parser.ParsePost(file)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	if !post.Draft || post.Markdown != page.Markdown {
+		t.Errorf("post Draft = %v, Markdown = %q", post.Draft, post.Markdown)
+	}
+}
+
 func TestParsePost_PopulatesAssets(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -399,6 +634,11 @@ func TestExtractDateAnchors(t *testing.T) {
 			markdown: "#### *2026-01-26* - Weekly update\n\nContent here.",
 			want:     []string{"2026-01-26"},
 		},
+		{
+			name:     "dates with times",
+			markdown: "## *2026-01-27 14:30*\n\nAfternoon.\n\n## *2026-01-27 09:05*\n\nMorning.",
+			want:     []string{"2026-01-27-1430", "2026-01-27-0905"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -703,6 +943,16 @@ func TestExtractFeedDateSections(t *testing.T) {
 			wantFirstDate: "2026-01-27",
 			wantFirstText: "<p>Paragraph one.</p>\n<ul><li>List item</li></ul>\n<p>Paragraph two.</p>",
 		},
+		{
+			name: "date sections with times",
+			html: `<h2 id="2026-01-27-1430"><a href="#2026-01-27-1430"><em>2026-01-27 14:30</em></a></h2>
+<p>Afternoon.</p>
+<h2 id="2026-01-27-0905"><a href="#2026-01-27-0905"><em>2026-01-27 09:05</em></a></h2>
+<p>Morning.</p>`,
+			wantSections:  2,
+			wantFirstDate: "2026-01-27-1430",
+			wantFirstText: "<p>Afternoon.</p>",
+		},
 	}
 
 	for _, tt := range tests {
@@ -725,116 +975,1193 @@ func TestExtractFeedDateSections(t *testing.T) {
 	}
 }
 
-func TestParseDateFromAnchor(t *testing.T) {
+func TestExtractFeedDateSections_Titles(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
 		name    string
-		anchor  string
-		wantErr bool
-		wantDay int
+		content string
+		want    string
 	}{
 		{
-			name:    "valid date anchor",
-			anchor:  "2026-01-27",
-			wantErr: false,
-			wantDay: 27,
+			name:    "first heading",
+			content: "<h3 id=\"release\"><a href=\"#release\">Shipped <em>v2</em></a></h3>\n<p>Finally. More text.</p>",
+			want:    "Shipped v2",
 		},
 		{
-			name:    "invalid date anchor",
-			anchor:  "not-a-date",
-			wantErr: true,
+			name:    "first sentence",
+			content: "<p>Read a <a href=\"/book/\">great book</a> today. It was long.</p>",
+			want:    "Read a great book today.",
 		},
 		{
-			name:    "empty anchor",
-			anchor:  "",
-			wantErr: true,
+			name:    "decimal point is not a sentence end",
+			content: "<p>Upgraded to Go 1.25! Builds are faster.</p>",
+			want:    "Upgraded to Go 1.25!",
+		},
+		{
+			name:    "long sentence cut at word boundary",
+			content: "<p>" + strings.Repeat("word ", 30) + "end.</p>",
+			want:    strings.TrimSpace(strings.Repeat("word ", 16)) + "…",
+		},
+		{
+			name:    "no heading or paragraph",
+			content: "<ul><li>List item</li></ul>",
+			want:    "",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			date, err := parser.ParseDateFromAnchor(tt.anchor)
-			if tt.wantErr {
-				if err == nil {
-					t.Error("ParseDateFromAnchor() expected error, got nil")
-				}
-				return
-			}
-			if err != nil {
-				t.Errorf("ParseDateFromAnchor() unexpected error = %v", err)
-				return
+			html := "<h2 id=\"2026-01-27\"><a href=\"#2026-01-27\">2026-01-27</a></h2>\n" + tt.content
+			sections := parser.ExtractFeedDateSections(html)
+			if len(sections) != 1 {
+				t.Fatalf("ExtractFeedDateSections() returned %d sections, want 1", len(sections))
 			}
-			if date.Day() != tt.wantDay {
-				t.Errorf("ParseDateFromAnchor() day = %d, want %d", date.Day(), tt.wantDay)
+			if sections[0].Title != tt.want {
+				t.Errorf("Title = %q, want %q", sections[0].Title, tt.want)
 			}
 		})
 	}
 }
 
-func TestMarkdownToHTML_HeadingIDs(t *testing.T) {
+func TestExtractFeedDateSections_Words(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		name         string
-		markdown     string
-		wantContains string
+		name    string
+		content string
+		want    int
 	}{
 		{
-			name:         "h1 gets ID and anchor",
-			markdown:     "# Hello",
-			wantContains: `<h1 id="hello"><a href="#hello">Hello</a></h1>`,
-		},
-		{
-			name:         "h2 gets ID and anchor",
-			markdown:     "## Features",
-			wantContains: `<h2 id="features"><a href="#features">Features</a></h2>`,
-		},
-		{
-			name:         "h3 gets ID and anchor",
-			markdown:     "### Getting Started",
-			wantContains: `<h3 id="getting-started"><a href="#getting-started">Getting Started</a></h3>`,
-		},
-		{
-			name:         "h4 gets ID and anchor",
-			markdown:     "#### Installation Steps",
-			wantContains: `<h4 id="installation-steps"><a href="#installation-steps">Installation Steps</a></h4>`,
+			name:    "paragraph text",
+			content: "<p>Read a <a href=\"/book/\">great book</a> today.</p>",
+			want:    5,
 		},
 		{
-			name:         "h5 gets ID and anchor",
-			markdown:     "##### Advanced Options",
-			wantContains: `<h5 id="advanced-options"><a href="#advanced-options">Advanced Options</a></h5>`,
+			name:    "headings and lists count",
+			content: "<h3 id=\"x\">Shipped v2</h3>\n<ul><li>One item</li></ul>",
+			want:    4,
 		},
 		{
-			name:         "h6 gets ID and anchor",
-			markdown:     "###### Footnotes",
-			wantContains: `<h6 id="footnotes"><a href="#footnotes">Footnotes</a></h6>`,
+			name:    "code blocks are skipped",
+			content: "<p>See below.</p>\n<pre><code>go build ./...\ngo test ./...</code></pre>",
+			want:    2,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			html := "<h2 id=\"2026-01-27\"><a href=\"#2026-01-27\">2026-01-27</a></h2>\n" + tt.content
+			sections := parser.ExtractFeedDateSections(html)
+			if len(sections) != 1 {
+				t.Fatalf("ExtractFeedDateSections() returned %d sections, want 1", len(sections))
+			}
+			if sections[0].Words != tt.want {
+				t.Errorf("Words = %d, want %d", sections[0].Words, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
 		{
-			name:         "spaces become hyphens in anchor",
-			markdown:     "## Hello World",
-			wantContains: `<h2 id="hello-world"><a href="#hello-world">Hello World</a></h2>`,
+			name: "inline elements join text",
+			html: "<p>Read <em>Go</em>'s <a href=\"/spec/\">spec</a>.</p>",
+			want: "Read Go's spec.",
 		},
 		{
-			name:         "special characters removed from ID but preserved in text",
-			markdown:     "## Hello World!",
-			wantContains: `<h2 id="hello-world"><a href="#hello-world">Hello World!</a></h2>`,
+			name: "blocks separate words",
+			html: "<p>One</p><p>two</p><ul><li>three</li><li>four</li></ul>",
+			want: "One two three four",
 		},
 		{
-			name:         "mixed case becomes lowercase in ID",
-			markdown:     "## Hello WORLD",
-			wantContains: `<h2 id="hello-world"><a href="#hello-world">Hello WORLD</a></h2>`,
+			name: "entities decoded and whitespace collapsed",
+			html: "<p>Fish &amp; chips\n  today</p>",
+			want: "Fish & chips today",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			html, err := parser.MarkdownToHTMLWithError(tt.markdown)
-			if err != nil {
-				t.Fatalf("MarkdownToHTMLWithError() error = %v", err)
+			if got := parser.PlainText(tt.html); got != tt.want {
+				t.Errorf("PlainText() = %q, want %q", got, tt.want)
 			}
-			if !strings.Contains(html, tt.wantContains) {
-				t.Errorf("MarkdownToHTMLWithError() = %q, want to contain %q", html, tt.wantContains)
+		})
+	}
+}
+
+func TestSplitArchiveMonths(t *testing.T) {
+	t.Parallel()
+
+	html := `<p>Intro.</p>
+<h2 id="2026-02-03"><a href="#2026-02-03">2026-02-03</a></h2>
+<p>February.</p>
+<h2 id="2026-01-15-0930"><a href="#2026-01-15-0930">2026-01-15 09:30</a></h2>
+<p>January.</p>
+<h3 id="notes"><a href="#notes">Notes</a></h3>
+<p>January notes.</p>
+<h2 id="2026-02-01"><a href="#2026-02-01">2026-02-01</a></h2>
+<p>Early February.</p>
+<h2 id="2025-12-20"><a href="#2025-12-20">2025-12-20</a></h2>
+<p>December.</p>
+<h2 id="2026-01-02"><a href="#2026-01-02">2026-01-02</a></h2>
+<p>Early January.</p>
+`
+
+	kept, months := parser.SplitArchiveMonths(html)
+
+	for _, want := range []string{"<p>Intro.</p>", "<p>February.</p>", "<p>Early February.</p>"} {
+		if !strings.Contains(kept, want) {
+			t.Errorf("kept content missing %q", want)
+		}
+	}
+	for _, notWant := range []string{"January", "December"} {
+		if strings.Contains(kept, notWant) {
+			t.Errorf("kept content should not contain %q", notWant)
+		}
+	}
+
+	if len(months) != 2 {
+		t.Fatalf("SplitArchiveMonths() returned %d months, want 2", len(months))
+	}
+	if got := months[0].Date.Format("2006-01"); got != "2026-01" {
+		t.Errorf("months[0] = %s, want 2026-01", got)
+	}
+	if got := strings.Join(months[0].Anchors, ","); got != "2026-01-15-0930,2026-01-02" {
+		t.Errorf("months[0].Anchors = %s, want 2026-01-15-0930,2026-01-02", got)
+	}
+	if !strings.Contains(months[0].Content, "<p>January notes.</p>") || !strings.Contains(months[0].Content, "<p>Early January.</p>") {
+		t.Errorf("months[0].Content = %q, want both January sections", months[0].Content)
+	}
+	if got := months[1].Date.Format("2006-01"); got != "2025-12" {
+		t.Errorf("months[1] = %s, want 2025-12", got)
+	}
+}
+
+func TestSplitArchiveMonths_NoDateSections(t *testing.T) {
+	t.Parallel()
+
+	html := "<p>Just a page.</p>"
+	kept, months := parser.SplitArchiveMonths(html)
+	if kept != html || months != nil {
+		t.Errorf("SplitArchiveMonths() = %q, %v, want content unchanged and no months", kept, months)
+	}
+}
+
+func TestTrimArchivedMarkdown(t *testing.T) {
+	t.Parallel()
+
+	markdown := "Intro.\n\n## *2026-03-02 09:15*\n\nMarch.\n\n" +
+		"### *2026-02-10*\n\n```\n## *2026-03-05*\n```\n\n" +
+		"## *2026-03-01*\n\nAlso March.\n"
+	archived := map[string]string{"2026-02-10": "/moments/2026/02/"}
+
+	want := "Intro.\n\n## *2026-03-02 09:15*\n\nMarch.\n\n## *2026-03-01*\n\nAlso March.\n"
+	if got := parser.TrimArchivedMarkdown(markdown, archived); got != want {
+		t.Errorf("TrimArchivedMarkdown() = %q, want %q", got, want)
+	}
+	if got := parser.TrimArchivedMarkdown("Just a page.", archived); got != "Just a page." {
+		t.Errorf("TrimArchivedMarkdown() = %q, want content unchanged", got)
+	}
+}
+
+func TestParseDateFromAnchor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		anchor  string
+		wantErr bool
+		wantDay int
+	}{
+		{
+			name:    "valid date anchor",
+			anchor:  "2026-01-27",
+			wantErr: false,
+			wantDay: 27,
+		},
+		{
+			name:    "date anchor with time",
+			anchor:  "2026-01-27-1430",
+			wantErr: false,
+			wantDay: 27,
+		},
+		{
+			name:    "invalid date anchor",
+			anchor:  "not-a-date",
+			wantErr: true,
+		},
+		{
+			name:    "invalid time",
+			anchor:  "2026-01-27-2561",
+			wantErr: true,
+		},
+		{
+			name:    "empty anchor",
+			anchor:  "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			date, err := parser.ParseDateFromAnchor(tt.anchor)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ParseDateFromAnchor() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ParseDateFromAnchor() unexpected error = %v", err)
+				return
+			}
+			if date.Day() != tt.wantDay {
+				t.Errorf("ParseDateFromAnchor() day = %d, want %d", date.Day(), tt.wantDay)
+			}
+		})
+	}
+}
+
+func TestMarkdownToHTML_HeadingIDs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		markdown     string
+		wantContains string
+	}{
+		{
+			name:         "h1 gets ID and anchor",
+			markdown:     "# Hello",
+			wantContains: `<h1 id="hello"><a href="#hello">Hello</a></h1>`,
+		},
+		{
+			name:         "h2 gets ID and anchor",
+			markdown:     "## Features",
+			wantContains: `<h2 id="features"><a href="#features">Features</a></h2>`,
+		},
+		{
+			name:         "h3 gets ID and anchor",
+			markdown:     "### Getting Started",
+			wantContains: `<h3 id="getting-started"><a href="#getting-started">Getting Started</a></h3>`,
+		},
+		{
+			name:         "h4 gets ID and anchor",
+			markdown:     "#### Installation Steps",
+			wantContains: `<h4 id="installation-steps"><a href="#installation-steps">Installation Steps</a></h4>`,
+		},
+		{
+			name:         "h5 gets ID and anchor",
+			markdown:     "##### Advanced Options",
+			wantContains: `<h5 id="advanced-options"><a href="#advanced-options">Advanced Options</a></h5>`,
+		},
+		{
+			name:         "h6 gets ID and anchor",
+			markdown:     "###### Footnotes",
+			wantContains: `<h6 id="footnotes"><a href="#footnotes">Footnotes</a></h6>`,
+		},
+		{
+			name:         "spaces become hyphens in anchor",
+			markdown:     "## Hello World",
+			wantContains: `<h2 id="hello-world"><a href="#hello-world">Hello World</a></h2>`,
+		},
+		{
+			name:         "special characters removed from ID but preserved in text",
+			markdown:     "## Hello World!",
+			wantContains: `<h2 id="hello-world"><a href="#hello-world">Hello World!</a></h2>`,
+		},
+		{
+			name:         "mixed case becomes lowercase in ID",
+			markdown:     "## Hello WORLD",
+			wantContains: `<h2 id="hello-world"><a href="#hello-world">Hello WORLD</a></h2>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			html, err := parser.MarkdownToHTMLWithError(tt.markdown)
+			if err != nil {
+				t.Fatalf("MarkdownToHTMLWithError() error = %v", err)
+			}
+			if !strings.Contains(html, tt.wantContains) {
+				t.Errorf("MarkdownToHTMLWithError() = %q, want to contain %q", html, tt.wantContains)
+			}
+		})
+	}
+}
+
+// writePNG writes a blank PNG image with the given dimensions.
+func writePNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParsePostWithOptions_Figures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		markdown        string
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:     "standalone image with title becomes captioned figure",
+			markdown: `![A cat](assets/cat.png "Our cat")`,
+			wantContains: []string{
+				"<figure>",
+				`<img src="assets/cat.png" alt="A cat" loading="lazy" decoding="async" width="40" height="30">`,
+				"<figcaption>Our cat</figcaption>",
+				"</figure>",
+			},
+			wantNotContains: []string{"<p><img", `title="Our cat"`},
+		},
+		{
+			name:            "standalone image without title has no caption",
+			markdown:        `![A cat](assets/cat.png)`,
+			wantContains:    []string{"<figure>", `width="40" height="30"`},
+			wantNotContains: []string{"<figcaption>"},
+		},
+		{
+			name:            "inline image stays in paragraph",
+			markdown:        `Look at ![A cat](assets/cat.png "Our cat") here.`,
+			wantContains:    []string{"<p>Look at <img", `loading="lazy"`, `title="Our cat"`},
+			wantNotContains: []string{"<figure>"},
+		},
+		{
+			name:            "remote image gets no dimensions",
+			markdown:        `![Remote](https://example.com/x.png "Remote")`,
+			wantContains:    []string{"<figure>", `loading="lazy" decoding="async">`},
+			wantNotContains: []string{"width="},
+		},
+		{
+			name:            "missing file gets no dimensions",
+			markdown:        `![Missing](assets/missing.png)`,
+			wantContains:    []string{"<figure>", `decoding="async">`},
+			wantNotContains: []string{"width="},
+		},
+		{
+			name:            "caption is escaped",
+			markdown:        `![x](assets/cat.png "Fish & <chips>")`,
+			wantContains:    []string{"<figcaption>Fish &amp; &lt;chips&gt;</figcaption>"},
+			wantNotContains: []string{"<chips>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			writePNG(t, filepath.Join(dir, "assets", "cat.png"), 40, 30)
+			file := filepath.Join(dir, "2024-01-15-cats.md")
+			content := "---\ntitle: Cats\n---\n" + tt.markdown + "\n"
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			post, err := parser.ParsePostWithOptions(file, parser.Options{Figures: true})
+			if err != nil {
+				t.Fatalf("ParsePostWithOptions() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(post.Content, want) {
+					t.Errorf("Content = %q, want to contain %q", post.Content, want)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(post.Content, notWant) {
+					t.Errorf("Content = %q, should not contain %q", post.Content, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePageWithOptions_RootRelativeImageUsesAssetsDir(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	assetsDir := filepath.Join(dir, "static")
+	writePNG(t, filepath.Join(assetsDir, "logo.png"), 64, 16)
+	file := filepath.Join(dir, "about.md")
+	if err := os.WriteFile(file, []byte("---\ntitle: About\n---\n![Logo](/logo.png)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := parser.ParsePageWithOptions(file, parser.Options{Figures: true, AssetsDir: assetsDir})
+	if err != nil {
+		t.Fatalf("ParsePageWithOptions() error = %v", err)
+	}
+	if !strings.Contains(page.Content, `width="64" height="16"`) {
+		t.Errorf("Content = %q, want dimensions from assets dir", page.Content)
+	}
+}
+
+func TestParsePost_FiguresDisabledByDefault(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "post.md")
+	if err := os.WriteFile(file, []byte(`![A cat](assets/cat.png "Our cat")`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	post, err := parser.ParsePost(file)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	want := `<p><img src="assets/cat.png" alt="A cat" title="Our cat"></p>`
+	if !strings.Contains(post.Content, want) {
+		t.Errorf("Content = %q, want %q", post.Content, want)
+	}
+}
+
+func TestParsePageWithOptions_WikiLinks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		markdown        string
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:         "title link",
+			markdown:     "See [[Getting Started]].",
+			wantContains: []string{`<a href="wikilink:Getting%20Started" class="wikilink">Getting Started</a>`},
+		},
+		{
+			name:         "slug link with label",
+			markdown:     "See [[about|the about page]].",
+			wantContains: []string{`<a href="wikilink:about" class="wikilink">the about page</a>`},
+		},
+		{
+			name:         "label is escaped",
+			markdown:     "[[about|Fish & <chips>]]",
+			wantContains: []string{`class="wikilink">Fish &amp; &lt;chips&gt;</a>`},
+		},
+		{
+			name:            "code span is left alone",
+			markdown:        "`[[about]]`",
+			wantContains:    []string{"<code>[[about]]</code>"},
+			wantNotContains: []string{"wikilink"},
+		},
+		{
+			name:            "regular link is unaffected",
+			markdown:        "[about](/about/)",
+			wantContains:    []string{`<a href="/about/">about</a>`},
+			wantNotContains: []string{"wikilink"},
+		},
+		{
+			name:            "empty target is not a link",
+			markdown:        "[[ ]]",
+			wantNotContains: []string{"wikilink"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			file := filepath.Join(dir, "notes.md")
+			if err := os.WriteFile(file, []byte(tt.markdown), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			page, err := parser.ParsePageWithOptions(file, parser.Options{WikiLinks: true})
+			if err != nil {
+				t.Fatalf("ParsePageWithOptions() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(page.Content, want) {
+					t.Errorf("Content = %q, want to contain %q", page.Content, want)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(page.Content, notWant) {
+					t.Errorf("Content = %q, should not contain %q", page.Content, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePage_WikiLinksDisabledByDefault(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(file, []byte("See [[about]]."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := parser.ParsePage(file)
+	if err != nil {
+		t.Fatalf("ParsePage() error = %v", err)
+	}
+	if !strings.Contains(page.Content, "See [[about]].") {
+		t.Errorf("Content = %q, want literal wiki link text", page.Content)
+	}
+}
+
+func TestResolveWikiLinks(t *testing.T) {
+	t.Parallel()
+	content := `<p><a href="wikilink:Getting%20Started" class="wikilink">Start</a> and <a href="wikilink:about" class="wikilink">about</a></p>`
+
+	var targets []string
+	got, err := parser.ResolveWikiLinks(content, func(target string) (string, error) {
+		targets = append(targets, target)
+		return "/" + strings.ToLower(strings.ReplaceAll(target, " ", "-")) + "/", nil
+	})
+	if err != nil {
+		t.Fatalf("ResolveWikiLinks() error = %v", err)
+	}
+	want := `<p><a href="/getting-started/" class="wikilink">Start</a> and <a href="/about/" class="wikilink">about</a></p>`
+	if got != want {
+		t.Errorf("ResolveWikiLinks() = %q, want %q", got, want)
+	}
+	if len(targets) != 2 || targets[0] != "Getting Started" || targets[1] != "about" {
+		t.Errorf("resolved targets = %v, want [Getting Started about]", targets)
+	}
+}
+
+func TestResolveWikiLinks_ReturnsResolveError(t *testing.T) {
+	t.Parallel()
+	content := `<a href="wikilink:missing" class="wikilink">missing</a>`
+
+	_, err := parser.ResolveWikiLinks(content, func(target string) (string, error) {
+		return "", fmt.Errorf("unresolved wiki link [[%s]]", target)
+	})
+	if err == nil || !strings.Contains(err.Error(), "[[missing]]") {
+		t.Errorf("ResolveWikiLinks() error = %v, want unresolved error for [[missing]]", err)
+	}
+}
+
+func TestResolveMarkdownWikiLinks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "target as label",
+			markdown: "Start at [[Getting Started]].",
+			want:     "Start at [Getting Started](/getting-started/).",
+		},
+		{
+			name:     "own label",
+			markdown: "# Notes\n\nRead [[about|the about page]] and [[Getting Started]].\n",
+			want:     "# Notes\n\nRead [the about page](/about/) and [Getting Started](/getting-started/).\n",
+		},
+		{
+			name:     "code left as written",
+			markdown: "Use `[[about]]` for links:\n\n```\n[[about]]\n```\n",
+			want:     "Use `[[about]]` for links:\n\n```\n[[about]]\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parser.ResolveMarkdownWikiLinks(tt.markdown, func(target string) (string, error) {
+				return "/" + strings.ToLower(strings.ReplaceAll(target, " ", "-")) + "/", nil
+			})
+			if err != nil {
+				t.Fatalf("ResolveMarkdownWikiLinks() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveMarkdownWikiLinks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePage_Math(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		markdown        string
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:         "inline math",
+			markdown:     "Energy is $E = mc^2$ here.",
+			wantContains: []string{"<p>Energy is <math><semantics>", "<msup><mi>c</mi><mn>2</mn></msup>", " here.</p>"},
+		},
+		{
+			name:            "display math block",
+			markdown:        "$$\n\\frac{a}{b}\n$$",
+			wantContains:    []string{`<math display="block">`, "<mfrac><mi>a</mi><mi>b</mi></mfrac>"},
+			wantNotContains: []string{"<p>"},
+		},
+		{
+			name:         "single line display math",
+			markdown:     "$$ x_1 $$",
+			wantContains: []string{`<math display="block">`, "<msub><mi>x</mi><mn>1</mn></msub>"},
+		},
+		{
+			name:            "currency is not math",
+			markdown:        "It costs $5 and $10.",
+			wantContains:    []string{"<p>It costs $5 and $10.</p>"},
+			wantNotContains: []string{"<math"},
+		},
+		{
+			name:            "code span is untouched",
+			markdown:        "Use `$x$` in source.",
+			wantContains:    []string{"<code>$x$</code>"},
+			wantNotContains: []string{"<math"},
+		},
+		{
+			name:            "code block is untouched",
+			markdown:        "```\n$$\nx\n$$\n```",
+			wantContains:    []string{"$$\nx\n$$"},
+			wantNotContains: []string{"<math"},
+		},
+		{
+			name:            "shell variables keep their dollar signs",
+			markdown:        "Set $HOME and $ USER$ first.",
+			wantContains:    []string{"<p>Set $HOME and $ USER$ first.</p>"},
+			wantNotContains: []string{"<math"},
+		},
+		{
+			name:            "inline display math needs non-space delimiters",
+			markdown:        "Pay $$ 5 $$ or $$x$$2 now.",
+			wantContains:    []string{"<p>Pay $$ 5 $$ or $$x$$2 now.</p>"},
+			wantNotContains: []string{"<math"},
+		},
+		{
+			name:            "unknown command is kept as text",
+			markdown:        `Bad $\nosuchcommand$ math.`,
+			wantContains:    []string{`<p>Bad $\nosuchcommand$ math.</p>`},
+			wantNotContains: []string{"<math"},
+		},
+		{
+			name:            "unclosed brace in a block is kept as text",
+			markdown:        "$$\nx_{1\n$$",
+			wantContains:    []string{"<p>$$x_{1\n$$</p>"},
+			wantNotContains: []string{"<math"},
+		},
+		{
+			name:            "escaped dollar is literal",
+			markdown:        `Price \$x$ only.`,
+			wantContains:    []string{"<p>Price $x$ only.</p>"},
+			wantNotContains: []string{"<math"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "math.md")
+			if err := os.WriteFile(file, []byte("---\ntitle: Math\n---\n"+tt.markdown+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			page, err := parser.ParsePageWithOptions(file, parser.Options{Math: true})
+			if err != nil {
+				t.Fatalf("ParsePageWithOptions() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(page.Content, want) {
+					t.Errorf("Content = %q, want to contain %q", page.Content, want)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(page.Content, notWant) {
+					t.Errorf("Content = %q, want not to contain %q", page.Content, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownToHTML_MathOffByDefault(t *testing.T) {
+	t.Parallel()
+	html, err := parser.MarkdownToHTMLWithError("Energy is $E = mc^2$ and $HOME/$USER.")
+	if err != nil {
+		t.Fatalf("MarkdownToHTMLWithError() error = %v", err)
+	}
+	if want := "<p>Energy is $E = mc^2$ and $HOME/$USER.</p>"; !strings.Contains(html, want) {
+		t.Errorf("MarkdownToHTMLWithError() = %q, want %q", html, want)
+	}
+}
+
+func TestParsePage_MathWarnings(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "math.md")
+	content := "---\ntitle: Math\n---\nGood $x^2$, bad $\\nosuchcommand$.\n\n$$\nx_{1\n$$\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := parser.ParsePageWithOptions(file, parser.Options{Math: true})
+	if err != nil {
+		t.Fatalf("ParsePageWithOptions() error = %v", err)
+	}
+	if len(page.Warnings) != 2 {
+		t.Fatalf("Warnings = %q, want 2", page.Warnings)
+	}
+	for i, want := range []string{file + `: math "$\\nosuchcommand$" kept as text: `, file + `: math "$$x_{1\n$$" kept as text: `} {
+		if !strings.HasPrefix(page.Warnings[i], want) {
+			t.Errorf("Warnings[%d] = %q, want prefix %q", i, page.Warnings[i], want)
+		}
+	}
+}
+
+// writeIncludeProject creates a project root with a content directory and
+// the given files, keyed by path relative to the root.
+func writeIncludeProject(t *testing.T, files map[string]string) (root, contentDir string) {
+	t.Helper()
+	root = t.TempDir()
+	contentDir = filepath.Join(root, "content")
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return root, contentDir
+}
+
+func TestParsePageWithOptions_Includes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		page            string
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:         "includes partial",
+			page:         "Intro\n\n{{< include \"disclaimer.md\" >}}\n\nOutro",
+			wantContains: []string{"<p>Intro</p>", "<p><em>Opinions are my own.</em></p>", "<p>Outro</p>"},
+		},
+		{
+			name:         "nested partials",
+			page:         `{{< include "outer.md" >}}`,
+			wantContains: []string{"<p>Outer</p>", "<p><em>Opinions are my own.</em></p>"},
+		},
+		{
+			name:            "embeds source line range",
+			page:            `{{< code "../main.go" 3-5 >}}`,
+			wantContains:    []string{"<pre><code class=\"language-go\">func main() {\n\tprintln(&quot;hi&quot;)\n}\n</code></pre>"},
+			wantNotContains: []string{"package main"},
+		},
+		{
+			name:         "embeds whole source file",
+			page:         `{{< code "../main.go" >}}`,
+			wantContains: []string{"package main", "func main()"},
+		},
+		{
+			name:            "directive in fenced code block is untouched",
+			page:            "```\n{{< include \"disclaimer.md\" >}}\n```",
+			wantContains:    []string{"{{&lt; include &quot;disclaimer.md&quot; &gt;}}"},
+			wantNotContains: []string{"Opinions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, contentDir := writeIncludeProject(t, map[string]string{
+				"main.go":                         "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+				"content/_partials/disclaimer.md": "---\ntitle: ignored\n---\n*Opinions are my own.*",
+				"content/_partials/outer.md":      "Outer\n\n{{< include \"disclaimer.md\" >}}",
+				"content/page.md":                 "---\ntitle: Page\n---\n" + tt.page,
+			})
+
+			page, err := parser.ParsePageWithOptions(filepath.Join(contentDir, "page.md"), parser.Options{ContentDir: contentDir})
+			if err != nil {
+				t.Fatalf("ParsePageWithOptions() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(page.Content, want) {
+					t.Errorf("Content = %q, want to contain %q", page.Content, want)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(page.Content, notWant) {
+					t.Errorf("Content = %q, want not to contain %q", page.Content, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePageWithOptions_IncludeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		page    string
+		wantErr []string
+	}{
+		{
+			name:    "cycle reports include chain",
+			page:    "Text\n\n{{< include \"a.md\" >}}",
+			wantErr: []string{"page.md:3:", "include cycle: ", "page.md -> ", "_partials/a.md -> ", "_partials/b.md -> ", "_partials/a.md"},
+		},
+		{
+			name:    "missing partial",
+			page:    `{{< include "missing.md" >}}`,
+			wantErr: []string{"page.md:1:", `include "missing.md"`},
+		},
+		{
+			name:    "path outside project root",
+			page:    `{{< code "../../outside.go" >}}`,
+			wantErr: []string{`code "../../outside.go"`, "outside the project root"},
+		},
+		{
+			name:    "line range past end of file",
+			page:    `{{< code "../main.go" 2-40 >}}`,
+			wantErr: []string{"line range 2-40 outside 1-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, contentDir := writeIncludeProject(t, map[string]string{
+				"project/main.go":                "package main",
+				"project/content/_partials/a.md": "{{< include \"b.md\" >}}",
+				"project/content/_partials/b.md": "{{< include \"a.md\" >}}",
+				"project/content/page.md":        tt.page,
+				"outside.go":                     "package outside",
+			})
+			contentDir = filepath.Join(root, "project", "content")
+
+			_, err := parser.ParsePageWithOptions(filepath.Join(contentDir, "page.md"), parser.Options{ContentDir: contentDir})
+			if err == nil {
+				t.Fatal("ParsePageWithOptions() expected error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ParsePageWithOptions() error = %q, want to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePage_IncludesDisabledWithoutContentDir(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.md")
+	if err := os.WriteFile(path, []byte(`{{< include "disclaimer.md" >}}`), 0644); err != nil {
+		t.Fatalf("failed to write page: %v", err)
+	}
+
+	page, err := parser.ParsePage(path)
+	if err != nil {
+		t.Fatalf("ParsePage() error = %v", err)
+	}
+	if !strings.Contains(page.Content, "include") {
+		t.Errorf("Content = %q, want directive left as text", page.Content)
+	}
+}
+
+func TestParsePost_FrontmatterFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "yaml",
+			content: "---\ntitle: Formats\nsummary: All of them\ndate: 2024-03-05\n---\nBody",
+		},
+		{
+			name:    "toml",
+			content: "+++\ntitle = \"Formats\"\nsummary = \"All of them\"\ndate = 2024-03-05\n+++\nBody",
+		},
+		{
+			name:    "toml quoted date",
+			content: "+++\ntitle = \"Formats\"\nsummary = \"All of them\"\ndate = \"2024-03-05\"\n+++\nBody",
+		},
+		{
+			name:    "json",
+			content: "{\n  \"title\": \"Formats\",\n  \"summary\": \"All of them\",\n  \"date\": \"2024-03-05\"\n}\nBody",
+		},
+		{
+			name:    "json on one line",
+			content: "{\"title\": \"Formats\", \"summary\": \"All of them\", \"date\": \"2024-03-05\"}\nBody",
+		},
+		{
+			name:    "json with nested objects",
+			content: "{\n  \"title\": \"Formats\",\n  \"params\": {\n    \"brace\": \"}\"\n}\n,\n  \"summary\": \"All of them\",\n  \"date\": \"2024-03-05\"\n}\nBody",
+		},
+		{
+			name:    "windows line endings",
+			content: "---\r\ntitle: Formats\r\nsummary: All of them\r\ndate: 2024-03-05\r\n---\r\nBody",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "formats.md")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			post, err := parser.ParsePost(file)
+			if err != nil {
+				t.Fatalf("ParsePost() error = %v", err)
+			}
+			if post.Title != "Formats" {
+				t.Errorf("Title = %q, want %q", post.Title, "Formats")
+			}
+			if post.Summary != "All of them" {
+				t.Errorf("Summary = %q, want %q", post.Summary, "All of them")
+			}
+			if want := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC); !post.Date.Equal(want) {
+				t.Errorf("Date = %v, want %v", post.Date, want)
+			}
+			if strings.TrimSpace(post.Content) != "<p>Body</p>" {
+				t.Errorf("Content = %q, want %q", post.Content, "<p>Body</p>")
+			}
+		})
+	}
+}
+
+func TestParsePage_FrontmatterDelimiterInValueAndBody(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "rule.md")
+	content := "---\ntitle: \"Before --- after\"\n---\nAbove\n\n---\n\nBelow"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := parser.ParsePage(file)
+	if err != nil {
+		t.Fatalf("ParsePage() error = %v", err)
+	}
+	if page.Title != "Before --- after" {
+		t.Errorf("Title = %q, want %q", page.Title, "Before --- after")
+	}
+	for _, want := range []string{"<p>Above</p>", "<hr>", "<p>Below</p>"} {
+		if !strings.Contains(page.Content, want) {
+			t.Errorf("Content = %q, want to contain %q", page.Content, want)
+		}
+	}
+}
+
+func TestParsePage_FrontmatterErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "yaml syntax error",
+			content: "---\ntitle: a\nsummary: b\n  c: d\n---\nBody",
+			wantErr: "x.md:4: invalid YAML frontmatter: mapping values are not allowed in this context",
+		},
+		{
+			name:    "yaml type error",
+			content: "---\ntitle: [1, 2]\n---\nBody",
+			wantErr: "x.md:2: invalid YAML frontmatter: cannot unmarshal !!seq into string",
+		},
+		{
+			name:    "toml syntax error",
+			content: "+++\ntitle = \"a\"\n\ndate =\n+++\nBody",
+			wantErr: "x.md:4: invalid TOML frontmatter: ",
+		},
+		{
+			name:    "toml type error",
+			content: "+++\ntitle = 5\n+++\nBody",
+			wantErr: "x.md:2: invalid TOML frontmatter: incompatible types",
+		},
+		{
+			name:    "json syntax error",
+			content: "{\n  \"title\": \"a\"\n  \"date\": \"2024-01-01\"\n}\nBody",
+			wantErr: "x.md:3: invalid JSON frontmatter: ",
+		},
+		{
+			name:    "unclosed json frontmatter",
+			content: "{\n  \"title\": \"a\",\n  \"tags\": [\"go\"\n",
+			wantErr: `x.md:1: JSON frontmatter is not closed with "}"`,
+		},
+		{
+			name:    "unclosed frontmatter",
+			content: "---\ntitle: a\nBody",
+			wantErr: `x.md:1: YAML frontmatter is not closed with "---"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "x.md")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := parser.ParsePage(file)
+			if err == nil {
+				t.Fatal("ParsePage() expected error")
+			}
+			if !strings.HasPrefix(err.Error(), file+":") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParsePage() error = %q, want %q prefixed by the file path", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePost_InvalidFrontmatterDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "yaml",
+			content: "---\ntitle: a\ndate: 2024-13-01\n---\nBody",
+			wantErr: "x.md:3: invalid date in frontmatter: ",
+		},
+		{
+			name:    "toml",
+			content: "+++\ntitle = \"a\"\n\ndate = \"March 5\"\n+++\nBody",
+			wantErr: "x.md:4: invalid date in frontmatter: ",
+		},
+		{
+			name:    "json on one line",
+			content: "{\"title\": \"a\", \"date\": \"2024-3-5\"}\nBody",
+			wantErr: "x.md:1: invalid date in frontmatter: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "x.md")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := parser.ParsePost(file)
+			if err == nil {
+				t.Fatal("ParsePost() expected error")
+			}
+			if !strings.HasPrefix(err.Error(), file+":") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParsePost() error = %q, want %q prefixed by the file path", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePost_FeedFrontmatter(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "2024-01-15-renamed.md")
+	content := "---\ntitle: Renamed\nauthor: Guest Writer\ntags: [go, feeds]\nguid: https://example.com/blog/original-slug/\n---\nBody"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	post, err := parser.ParsePost(file)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	if post.Author != "Guest Writer" {
+		t.Errorf("Author = %q, want %q", post.Author, "Guest Writer")
+	}
+	if strings.Join(post.Tags, ",") != "go,feeds" {
+		t.Errorf("Tags = %v, want [go feeds]", post.Tags)
+	}
+	if post.GUID != "https://example.com/blog/original-slug/" {
+		t.Errorf("GUID = %q, want original URL", post.GUID)
+	}
+}
+
+func TestParsePost_MediaFrontmatter(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "assets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "assets", "episode-1.mp3"), []byte("0123456789"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "assets", "clip.webm"), []byte("webm"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "2024-01-15-episode.md")
+	content := "---\ntitle: Episode 1\naudio: assets/episode-1.mp3\nvideo: assets/clip.webm\nduration: \"32:15\"\nepisode: 1\n---\nShow notes"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	post, err := parser.ParsePost(file)
+	if err != nil {
+		t.Fatalf("ParsePost() error = %v", err)
+	}
+	wantAudio := model.Media{Path: "assets/episode-1.mp3", Type: "audio/mpeg", Length: 10}
+	if post.Audio == nil || *post.Audio != wantAudio {
+		t.Errorf("Audio = %+v, want %+v", post.Audio, wantAudio)
+	}
+	wantVideo := model.Media{Path: "assets/clip.webm", Type: "video/webm", Length: 4}
+	if post.Video == nil || *post.Video != wantVideo {
+		t.Errorf("Video = %+v, want %+v", post.Video, wantVideo)
+	}
+	if post.Duration != "32:15" || post.Episode != 1 {
+		t.Errorf("Duration, Episode = %q, %d, want \"32:15\", 1", post.Duration, post.Episode)
+	}
+	if strings.Join(post.Assets, ",") != "assets/episode-1.mp3,assets/clip.webm" {
+		t.Errorf("Assets = %v, want media files to be copied", post.Assets)
+	}
+}
+
+func TestParsePost_MediaFrontmatterErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		field   string
+		wantErr string
+	}{
+		{name: "missing file", field: "audio: assets/missing.mp3", wantErr: `audio "assets/missing.mp3"`},
+		{name: "outside assets", field: "audio: episode.mp3", wantErr: "must be an asset path"},
+		{name: "unsupported audio type", field: "audio: assets/episode.txt", wantErr: "unsupported file type"},
+		{name: "video as audio", field: "audio: assets/clip.mp4", wantErr: "unsupported file type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "2024-01-15-episode.md")
+			content := "---\ntitle: Episode\n" + tt.field + "\n---\nBody"
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := parser.ParsePost(file)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParsePost() error = %v, want %q", err, tt.wantErr)
 			}
 		})
 	}
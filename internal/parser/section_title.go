@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/jeroendee/ssg/internal/textcount"
+	"golang.org/x/net/html"
+)
+
+// maxSectionTitle is the maximum length in runes of a derived section title.
+const maxSectionTitle = 80
+
+// sectionTitle derives a title from the HTML content of a date section: the
+// text of its first heading, or else the first sentence of its first
+// paragraph. Long titles are cut at a word boundary. It returns an empty
+// string when the section starts with neither.
+func sectionTitle(content string) string {
+	z := html.NewTokenizer(strings.NewReader(content))
+	var text strings.Builder
+	var block string
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return finishSectionTitle(block, text.String())
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if block == "" && isTitleBlock(string(name)) {
+				block = string(name)
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			if block != "" && string(name) == block {
+				return finishSectionTitle(block, text.String())
+			}
+		case html.TextToken:
+			if block != "" {
+				text.Write(z.Text())
+			}
+		}
+	}
+}
+
+// isTitleBlock reports whether an element can provide a section title.
+func isTitleBlock(name string) bool {
+	switch name {
+	case "h1", "h2", "h3", "h4", "h5", "h6", "p":
+		return true
+	}
+	return false
+}
+
+// finishSectionTitle normalizes the text of block into a title.
+func finishSectionTitle(block, text string) string {
+	title := strings.Join(strings.Fields(text), " ")
+	if block == "p" {
+		title = firstSentence(title)
+	}
+	return truncateWords(title, maxSectionTitle)
+}
+
+// firstSentence returns text up to and including its first sentence-ending
+// punctuation that is followed by a space.
+func firstSentence(text string) string {
+	for i, r := range text {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		next := i + 1
+		if next == len(text) || text[next] == ' ' {
+			return text[:next]
+		}
+	}
+	return text
+}
+
+// truncateWords shortens text to at most limit runes, cutting at the last
+// word boundary and appending an ellipsis.
+func truncateWords(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	cut := string(runes[:limit])
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRightFunc(cut, unicode.IsPunct) + "…"
+}
+
+// sectionWords counts the words in the text of the HTML content of a date
+// section, skipping preformatted code blocks.
+func sectionWords(content string) int {
+	z := html.NewTokenizer(strings.NewReader(content))
+	words, pre := 0, 0
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return words
+		case html.StartTagToken:
+			if name, _ := z.TagName(); string(name) == "pre" {
+				pre++
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "pre" && pre > 0 {
+				pre--
+			}
+		case html.TextToken:
+			if pre == 0 {
+				words += textcount.Count(html.UnescapeString(string(z.Text()))).Total()
+			}
+		}
+	}
+}
+
+// inlineElements are the elements whose text runs on with the text around
+// them when converting HTML to plain text.
+var inlineElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "code": true, "del": true, "em": true,
+	"i": true, "ins": true, "kbd": true, "mark": true, "s": true, "small": true,
+	"span": true, "strong": true, "sub": true, "sup": true,
+}
+
+// PlainText returns the text of HTML content with tags removed, entities
+// decoded and whitespace collapsed. Block elements separate words.
+func PlainText(content string) string {
+	z := html.NewTokenizer(strings.NewReader(content))
+	var text strings.Builder
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return strings.Join(strings.Fields(text.String()), " ")
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			if name, _ := z.TagName(); !inlineElements[string(name)] {
+				text.WriteByte(' ')
+			}
+		case html.TextToken:
+			text.Write(z.Text())
+		}
+	}
+}
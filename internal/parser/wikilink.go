@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"bytes"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	gmparser "github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// wikiLinkScheme prefixes the href of a wiki link until it is resolved.
+const wikiLinkScheme = "wikilink:"
+
+// kindWikiLink is the node kind for wiki links.
+var kindWikiLink = ast.NewNodeKind("WikiLink")
+
+// wikiLink is an inline node for [[target]] and [[target|label]] links.
+type wikiLink struct {
+	ast.BaseInline
+	Target []byte
+	Label  []byte
+	Source text.Segment // The [[...]] syntax in the markdown source
+}
+
+// Kind returns the wiki link node kind.
+func (n *wikiLink) Kind() ast.NodeKind {
+	return kindWikiLink
+}
+
+// Dump dumps the wiki link node for debugging.
+func (n *wikiLink) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"Target": string(n.Target),
+		"Label":  string(n.Label),
+	}, nil)
+}
+
+// wikiLinkParser parses [[target]] and [[target|label]] inline syntax.
+type wikiLinkParser struct{}
+
+// Trigger returns the characters that start a wiki link.
+func (p *wikiLinkParser) Trigger() []byte {
+	return []byte{'['}
+}
+
+// Parse parses a wiki link at the current position.
+func (p *wikiLinkParser) Parse(parent ast.Node, block text.Reader, pc gmparser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	if !bytes.HasPrefix(line, []byte("[[")) {
+		return nil
+	}
+	end := bytes.Index(line[2:], []byte("]]"))
+	if end < 0 {
+		return nil
+	}
+	inner := line[2 : 2+end]
+	if bytes.ContainsAny(inner, "[]") {
+		return nil
+	}
+
+	target, label := inner, inner
+	if i := bytes.IndexByte(inner, '|'); i >= 0 {
+		target, label = inner[:i], inner[i+1:]
+	}
+	target = bytes.TrimSpace(target)
+	label = bytes.TrimSpace(label)
+	if len(target) == 0 {
+		return nil
+	}
+	if len(label) == 0 {
+		label = target
+	}
+
+	block.Advance(end + 4)
+	return &wikiLink{
+		Target: append([]byte(nil), target...),
+		Label:  append([]byte(nil), label...),
+		Source: text.NewSegment(segment.Start, segment.Start+end+4),
+	}
+}
+
+// wikiLinkRenderer renders wiki links as anchors with a placeholder href.
+type wikiLinkRenderer struct {
+	gmhtml.Config
+}
+
+// newWikiLinkRenderer returns a new renderer for wiki link nodes.
+func newWikiLinkRenderer(opts ...gmhtml.Option) renderer.NodeRenderer {
+	r := &wikiLinkRenderer{
+		Config: gmhtml.NewConfig(),
+	}
+	for _, opt := range opts {
+		opt.SetHTMLOption(&r.Config)
+	}
+	return r
+}
+
+// RegisterFuncs registers the wiki link renderer function.
+func (r *wikiLinkRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindWikiLink, r.renderWikiLink)
+}
+
+// renderWikiLink renders a wiki link; the href is resolved later by [ResolveWikiLinks].
+func (r *wikiLinkRenderer) renderWikiLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*wikiLink)
+	_, _ = w.WriteString(`<a href="`)
+	_, _ = w.WriteString(html.EscapeString(wikiLinkScheme + url.PathEscape(string(n.Target))))
+	_, _ = w.WriteString(`" class="wikilink">`)
+	_, _ = w.Write(util.EscapeHTML(n.Label))
+	_, _ = w.WriteString("</a>")
+	return ast.WalkSkipChildren, nil
+}
+
+// wikiLinkHrefRegex matches unresolved wiki link hrefs in rendered HTML.
+var wikiLinkHrefRegex = regexp.MustCompile(`href="` + wikiLinkScheme + `([^"]*)"`)
+
+// ResolveWikiLinks replaces wiki link placeholders in rendered HTML with the
+// URLs returned by resolve. The first resolve error is returned.
+func ResolveWikiLinks(content string, resolve func(target string) (string, error)) (string, error) {
+	var resolveErr error
+	result := wikiLinkHrefRegex.ReplaceAllStringFunc(content, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		target := decodeWikiLinkTarget(wikiLinkHrefRegex.FindStringSubmatch(match)[1])
+		u, err := resolve(target)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		return `href="` + html.EscapeString(u) + `"`
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return result, nil
+}
+
+// decodeWikiLinkTarget reverses the escaping applied when rendering a wiki link href.
+func decodeWikiLinkTarget(escaped string) string {
+	target := html.UnescapeString(escaped)
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		return unescaped
+	}
+	return target
+}
+
+// ResolveMarkdownWikiLinks replaces the wiki links in markdown with markdown
+// links to the URLs returned by resolve, so [[target|label]] becomes
+// [label](url). Wiki link syntax in code is left as written. The first
+// resolve error is returned.
+func ResolveMarkdownWikiLinks(markdown string, resolve func(target string) (string, error)) (string, error) {
+	source := []byte(markdown)
+	doc := converterFor(Options{WikiLinks: true}).Parser().Parse(text.NewReader(source))
+
+	var links []*wikiLink
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := n.(*wikiLink); ok && entering {
+			links = append(links, link)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	var b strings.Builder
+	last := 0
+	for _, link := range links {
+		u, err := resolve(string(link.Target))
+		if err != nil {
+			return "", err
+		}
+		b.Write(source[last:link.Source.Start])
+		b.WriteString("[" + string(link.Label) + "](" + u + ")")
+		last = link.Source.Stop
+	}
+	b.Write(source[last:])
+	return b.String(), nil
+}
@@ -10,11 +10,25 @@
 //
 //   - Homepage (index.html)
 //   - Static pages (/about/, /contact/, etc.)
-//   - Blog listing (/blog/)
+//   - Blog listing (/blog/), with each post's excerpt or summary
 //   - Individual blog posts (/blog/slug/)
+//   - On this day pages (/moments/on-this-day/)
+//   - Topic pages (/moments/topics/word/)
+//   - Topic index (/topics/)
 //   - 404 error page
 //
+// Pages with date sections show an inline SVG calendar heatmap per year,
+// linking each day to its first section.
+//
 // All templates include navigation, consistent styling, and link to /style.css.
+// Pages and posts take their meta description, canonical URL, robots meta
+// tag and social card image from frontmatter when set, and link their
+// markdown copy as a text/markdown alternate when they have one.
+//
+// JSON-LD structured data is built from Go structs and marshalled with
+// [encoding/json]: a WebSite on every page, a WebPage, CollectionPage or
+// BlogPosting for the page itself, and a BreadcrumbList for nested pages
+// through the ancestor pages that exist.
 //
 // # Usage
 //
@@ -36,5 +50,27 @@
 // It accepts raw HTML content and wraps it with the site's base template.
 //
 // RenderFeed generates an RSS 2.0 feed from blog posts. It includes up to
-// 20 most recent posts with full content wrapped in CDATA sections.
+// the site's feed limit (20 by default) of the most recent posts with full
+// content, or summaries or excerpts when configured, wrapped in CDATA
+// sections.
+//
+// RenderAtomFeed and RenderJSONFeed generate Atom 1.0 and JSON Feed 1.1
+// documents from the same feed items, using item GUIDs as stable IDs.
+// All feeds resolve relative src and href attributes in item content
+// against the item's absolute URL.
+//
+// RenderSectionFeed generates the RSS 2.0 feed of a single section, such as
+// the blog, a tag or a feed page, published as index.xml in that section.
+// Pages of a section with a feed link to it from their head; posts link
+// to the feeds of their tags, which have no HTML page.
+//
+// RenderOnThisDay renders the date sections from earlier years that share a
+// given month and day, with a script that re-renders them from index.json
+// when the visitor's day differs from the build day.
+//
+// RenderTopic renders the date sections mentioning a topic, with snippets
+// in which the topic word is highlighted.
+//
+// RenderTopicIndex renders the site-wide topics as a tag cloud weighted by
+// count, followed by the posts and date sections mentioning each topic.
 package renderer
@@ -0,0 +1,231 @@
+package renderer
+
+import (
+	"cmp"
+	"encoding/json"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/jeroendee/ssg/internal/model"
+)
+
+// schemaContext is the JSON-LD context of all structured data.
+const schemaContext = "https://schema.org"
+
+// ldWebSite is the schema.org WebSite of every page.
+type ldWebSite struct {
+	Context     string `json:"@context"`
+	Type        string `json:"@type"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// ldWebPage is a schema.org WebPage, or a CollectionPage for listings.
+type ldWebPage struct {
+	Context     string    `json:"@context"`
+	Type        string    `json:"@type"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	Description string    `json:"description,omitempty"`
+	Image       string    `json:"image,omitempty"`
+	InLanguage  string    `json:"inLanguage,omitempty"`
+	Author      *ldPerson `json:"author,omitempty"`
+}
+
+// ldBlogPosting is the schema.org BlogPosting of a post.
+type ldBlogPosting struct {
+	Context          string          `json:"@context"`
+	Type             string          `json:"@type"`
+	Headline         string          `json:"headline"`
+	Description      string          `json:"description,omitempty"`
+	URL              string          `json:"url"`
+	Image            string          `json:"image,omitempty"`
+	DatePublished    string          `json:"datePublished"`
+	DateModified     string          `json:"dateModified"`
+	TimeRequired     string          `json:"timeRequired,omitempty"` // ISO 8601 duration, e.g. PT5M
+	WordCount        int             `json:"wordCount,omitempty"`
+	Keywords         []string        `json:"keywords,omitempty"`
+	InLanguage       string          `json:"inLanguage,omitempty"`
+	Author           *ldPerson       `json:"author,omitempty"`
+	Publisher        ldOrganization  `json:"publisher"`
+	MainEntityOfPage ldMainEntityRef `json:"mainEntityOfPage"`
+}
+
+// ldPerson is a schema.org Person, such as the author of a page.
+type ldPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// ldOrganization is the schema.org Organization publishing the site.
+type ldOrganization struct {
+	Type string   `json:"@type"`
+	Name string   `json:"name"`
+	URL  string   `json:"url"`
+	Logo *ldImage `json:"logo,omitempty"`
+}
+
+// ldImage is a schema.org ImageObject.
+type ldImage struct {
+	Type string `json:"@type"`
+	URL  string `json:"url"`
+}
+
+// ldMainEntityRef refers to the WebPage a post is the main entity of.
+type ldMainEntityRef struct {
+	Type string `json:"@type"`
+	ID   string `json:"@id"`
+}
+
+// ldBreadcrumbList is the schema.org BreadcrumbList of a nested page.
+type ldBreadcrumbList struct {
+	Context         string       `json:"@context"`
+	Type            string       `json:"@type"`
+	ItemListElement []ldListItem `json:"itemListElement"`
+}
+
+// ldListItem is a crumb of a BreadcrumbList.
+type ldListItem struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+	Item     string `json:"item"`
+}
+
+// siteStructuredData returns the JSON-LD of a page outside the site's
+// content, such as the 404 page: only the WebSite.
+func siteStructuredData(site model.Site) []template.JS {
+	return structuredData(webSiteLD(site))
+}
+
+// pageStructuredData returns the JSON-LD of the page at pagePath: the
+// WebSite, the page itself and, when the page is nested, its breadcrumbs.
+// The context and site author of page are filled in.
+func pageStructuredData(site model.Site, pagePath string, page ldWebPage) []template.JS {
+	page.Context = schemaContext
+	page.Author = personLD(site.Author)
+	nodes := []any{webSiteLD(site), page}
+	if crumbs, ok := breadcrumbsLD(site, pagePath, page.Name); ok {
+		nodes = append(nodes, crumbs)
+	}
+	return structuredData(nodes...)
+}
+
+// postStructuredData returns the JSON-LD of post: the WebSite, the
+// BlogPosting and its breadcrumbs. Posts carry no modification date, so
+// dateModified is the publication date.
+func postStructuredData(site model.Site, post model.Post, canonical, description, image string) []template.JS {
+	date := post.Date.Format("2006-01-02")
+	posting := ldBlogPosting{
+		Context:          schemaContext,
+		Type:             "BlogPosting",
+		Headline:         post.Title,
+		Description:      description,
+		URL:              canonical,
+		Image:            image,
+		DatePublished:    date,
+		DateModified:     date,
+		WordCount:        post.WordCount,
+		Keywords:         post.Tags,
+		InLanguage:       post.Lang,
+		Author:           personLD(cmp.Or(post.Author, site.Author)),
+		Publisher:        publisherLD(site),
+		MainEntityOfPage: ldMainEntityRef{Type: "WebPage", ID: canonical},
+	}
+	if post.ReadingTime > 0 {
+		posting.TimeRequired = "PT" + strconv.Itoa(post.ReadingTime) + "M"
+	}
+	nodes := []any{webSiteLD(site), posting}
+	if crumbs, ok := breadcrumbsLD(site, post.Path, post.Title); ok {
+		nodes = append(nodes, crumbs)
+	}
+	return structuredData(nodes...)
+}
+
+// webSiteLD returns the WebSite of site.
+func webSiteLD(site model.Site) ldWebSite {
+	return ldWebSite{
+		Context:     schemaContext,
+		Type:        "WebSite",
+		Name:        site.Title,
+		URL:         site.BaseURL,
+		Description: site.Description,
+	}
+}
+
+// personLD returns the Person named name, or nil without a name.
+func personLD(name string) *ldPerson {
+	if name == "" {
+		return nil
+	}
+	return &ldPerson{Type: "Person", Name: name}
+}
+
+// publisherLD returns the site as the Organization publishing its posts,
+// with the site logo when set.
+func publisherLD(site model.Site) ldOrganization {
+	org := ldOrganization{Type: "Organization", Name: site.Title, URL: site.BaseURL + "/"}
+	if site.Logo != "" {
+		org.Logo = &ldImage{Type: "ImageObject", URL: siteURL(site, "/", site.Logo)}
+	}
+	return org
+}
+
+// breadcrumbsLD returns the breadcrumbs of the page at pagePath named name,
+// from the home page through the ancestor pages that exist, such as the blog
+// for /blog/slug/ or a page for its archives. It reports false for pages
+// that are not nested.
+func breadcrumbsLD(site model.Site, pagePath, name string) (ldBreadcrumbList, bool) {
+	segments := strings.Split(strings.Trim(pagePath, "/"), "/")
+	if len(segments) < 2 {
+		return ldBreadcrumbList{}, false
+	}
+	list := ldBreadcrumbList{Context: schemaContext, Type: "BreadcrumbList"}
+	add := func(name, item string) {
+		list.ItemListElement = append(list.ItemListElement, ldListItem{
+			Type:     "ListItem",
+			Position: len(list.ItemListElement) + 1,
+			Name:     name,
+			Item:     item,
+		})
+	}
+	add(site.Title, site.BaseURL+"/")
+	for i := 1; i < len(segments); i++ {
+		parent := "/" + strings.Join(segments[:i], "/") + "/"
+		if title, ok := pageTitle(site, parent); ok {
+			add(title, site.BaseURL+parent)
+		}
+	}
+	add(name, site.BaseURL+pagePath)
+	return list, true
+}
+
+// pageTitle returns the title of the page at pagePath, or "Blog" for the
+// blog listing, and reports whether the site has such a page.
+func pageTitle(site model.Site, pagePath string) (string, bool) {
+	if pagePath == "/blog/" {
+		return "Blog", true
+	}
+	for _, page := range site.Pages {
+		if page.Path == pagePath {
+			return page.Title, true
+		}
+	}
+	return "", false
+}
+
+// structuredData marshals nodes to indented JSON for JSON-LD script
+// elements. encoding/json escapes <, > and &, so no value can end the
+// script element early.
+func structuredData(nodes ...any) []template.JS {
+	scripts := make([]template.JS, 0, len(nodes))
+	for _, node := range nodes {
+		// The node types hold only strings, numbers and slices of them,
+		// which always marshal.
+		b, _ := json.MarshalIndent(node, "    ", "    ")
+		scripts = append(scripts, template.JS(b))
+	}
+	return scripts
+}
@@ -2,12 +2,21 @@ package renderer
 
 import (
 	"bytes"
+	"cmp"
 	"embed"
+	"encoding/json"
 	"encoding/xml"
 	"html/template"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jeroendee/ssg/internal/model"
+	"github.com/jeroendee/ssg/internal/topics"
+	"golang.org/x/net/html"
 )
 
 //go:embed templates/*.html
@@ -46,7 +55,12 @@ type templateData struct {
 	Summary      string
 	IsPost       bool
 	OGImage      string
+	NoIndex      bool
+	LargeCard    bool // OGImage is the page's own image, shown as a large card
 	Version      string
+	SectionFeeds []model.FeedLink
+	JSONLD       []template.JS // Structured data, one script element each
+	MarkdownURL  string        // Markdown copy of the page; empty without one
 	PageType     string
 	Content      template.HTML
 	Page         struct {
@@ -56,6 +70,10 @@ type templateData struct {
 		CurrentMonthDates []string
 		ArchivedYears     []model.YearGroup
 		Topics            []model.Topic
+		Backlinks         []model.Backlink
+		ArchivedAnchors   map[string]string
+		OnThisDayPath     string
+		Heatmaps          []heatmapYear
 	}
 }
 
@@ -65,6 +83,9 @@ type blogPostItem struct {
 	Slug          string
 	DateFormatted string
 	WordCount     int
+	ReadingTime   int
+	Summary       string
+	Excerpt       template.HTML // Excerpt with URLs resolved against the post
 }
 
 // blogListData holds data for blog list template rendering.
@@ -75,25 +96,39 @@ type blogListData struct {
 	Summary      string
 	IsPost       bool
 	OGImage      string
+	NoIndex      bool
+	LargeCard    bool
 	Version      string
+	SectionFeeds []model.FeedLink
+	JSONLD       []template.JS
+	MarkdownURL  string
 	Posts        []blogPostItem
 }
 
 // blogPostData holds data for blog post template rendering.
 type blogPostData struct {
-	Site          model.Site
-	PageTitle     string
-	CanonicalURL  string
-	Summary       string
-	IsPost        bool
-	OGImage       string
-	Version       string
-	DatePublished string
-	Post          struct {
+	Site         model.Site
+	PageTitle    string
+	CanonicalURL string
+	Summary      string
+	IsPost       bool
+	OGImage      string
+	NoIndex      bool
+	LargeCard    bool
+	Version      string
+	SectionFeeds []model.FeedLink
+	JSONLD       []template.JS
+	MarkdownURL  string
+	Post         struct {
 		Title         string
 		DateFormatted string
 		Content       template.HTML
 		WordCount     int
+		ReadingTime   int
+		Backlinks     []model.Backlink
+		Audio         *model.Media
+		Video         *model.Media
+		Topics        []model.Topic
 	}
 }
 
@@ -104,6 +139,7 @@ func (r *Renderer) RenderBase(site model.Site, content string) (string, error) {
 		Content:  template.HTML(content),
 		Version:  r.version,
 		PageType: "base",
+		JSONLD:   siteStructuredData(site),
 	}
 
 	var buf bytes.Buffer
@@ -115,14 +151,19 @@ func (r *Renderer) RenderBase(site model.Site, content string) (string, error) {
 
 // RenderPage renders a static page with the page template.
 func (r *Renderer) RenderPage(site model.Site, page model.Page) (string, error) {
+	image, large := pageImageURL(site, page)
 	data := templateData{
 		Site:         site,
 		PageTitle:    page.Title,
-		CanonicalURL: site.BaseURL + "/" + page.Slug + "/",
-		Summary:      site.Description,
+		CanonicalURL: canonicalURL(site, page, site.BaseURL+"/"+page.Slug+"/"),
+		Summary:      cmp.Or(page.Description, site.Description),
 		IsPost:       false,
-		OGImage:      ogImageURL(site),
+		OGImage:      image,
+		NoIndex:      page.NoIndex,
+		LargeCard:    large,
 		Version:      r.version,
+		SectionFeeds: sectionFeeds(site, page.Path),
+		MarkdownURL:  page.MarkdownPath,
 		PageType:     "page",
 	}
 	data.Page.Title = page.Title
@@ -131,6 +172,18 @@ func (r *Renderer) RenderPage(site model.Site, page model.Page) (string, error)
 	data.Page.CurrentMonthDates = page.CurrentMonthDates
 	data.Page.ArchivedYears = page.ArchivedYears
 	data.Page.Topics = page.Topics
+	data.Page.Backlinks = page.Backlinks
+	data.Page.ArchivedAnchors = page.ArchivedAnchors
+	data.Page.OnThisDayPath = page.OnThisDayPath
+	data.Page.Heatmaps = heatmaps(page.Calendar)
+	data.JSONLD = pageStructuredData(site, page.Path, ldWebPage{
+		Type:        "WebPage",
+		Name:        page.Title,
+		URL:         data.CanonicalURL,
+		Description: data.Summary,
+		Image:       data.OGImage,
+		InLanguage:  page.Lang,
+	})
 
 	var buf bytes.Buffer
 	if err := r.templates.ExecuteTemplate(&buf, "base.html", data); err != nil {
@@ -139,6 +192,123 @@ func (r *Renderer) RenderPage(site model.Site, page model.Page) (string, error)
 	return buf.String(), nil
 }
 
+// sectionFeeds returns the feed link of the section at sectionPath, or nil
+// when the section has no feed of its own.
+func sectionFeeds(site model.Site, sectionPath string) []model.FeedLink {
+	if link, ok := site.SectionFeedLink(sectionPath); ok {
+		return []model.FeedLink{link}
+	}
+	return nil
+}
+
+// tagFeeds returns the feed links of the tags of post, which have no HTML
+// section of their own to be advertised from.
+func tagFeeds(site model.Site, post model.Post) []model.FeedLink {
+	var links []model.FeedLink
+	seen := make(map[string]bool)
+	for _, tag := range post.Tags {
+		slug := model.TagSlug(tag)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		links = append(links, sectionFeeds(site, model.TagGroup{Slug: slug}.TagPath())...)
+	}
+	return links
+}
+
+// Calendar heatmap geometry in SVG user units.
+const (
+	heatmapSize   = 10 // Side of a day cell
+	heatmapStep   = 12 // Distance between neighbouring cells
+	heatmapTop    = 16 // Space above the grid for month labels
+	heatmapLevels = 4  // Intensity levels of days with sections
+)
+
+// heatmapYear is the calendar heatmap of one year, laid out as GitHub-style
+// columns of weeks starting on Sunday.
+type heatmapYear struct {
+	Year     int
+	Width    int
+	Height   int
+	CellSize int
+	Months   []heatmapLabel
+	Cells    []heatmapCell
+}
+
+// heatmapLabel is a month name above the week its first day falls in.
+type heatmapLabel struct {
+	X    int
+	Name string
+}
+
+// heatmapCell is a day of a calendar heatmap.
+type heatmapCell struct {
+	X, Y  int
+	Date  string
+	Words int
+	Level int    // 0 for days without sections, up to heatmapLevels by length
+	URL   string // Link to the day's first section; empty without sections
+}
+
+// heatmaps lays out calendar heatmaps for the years of days, newest year
+// first. Intensity levels are relative to the longest day of all years.
+func heatmaps(days []model.CalendarDay) []heatmapYear {
+	if len(days) == 0 {
+		return nil
+	}
+
+	byDate := make(map[string]model.CalendarDay, len(days))
+	longest := 0
+	for _, day := range days {
+		byDate[day.Date.Format("2006-01-02")] = day
+		longest = max(longest, day.Words)
+	}
+
+	var years []heatmapYear
+	for year := days[len(days)-1].Date.Year(); year >= days[0].Date.Year(); year-- {
+		first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		offset := int(first.Weekday())
+		hm := heatmapYear{Year: year, CellSize: heatmapSize}
+		hasDays := false
+		for date := first; date.Year() == year; date = date.AddDate(0, 0, 1) {
+			week := (date.YearDay() - 1 + offset) / 7
+			x := week * heatmapStep
+			if date.Day() == 1 {
+				hm.Months = append(hm.Months, heatmapLabel{X: x, Name: date.Format("Jan")})
+			}
+			cell := heatmapCell{
+				X:    x,
+				Y:    heatmapTop + int(date.Weekday())*heatmapStep,
+				Date: date.Format("2006-01-02"),
+			}
+			if day, ok := byDate[cell.Date]; ok {
+				hasDays = true
+				cell.Words = day.Words
+				cell.URL = day.URL
+				cell.Level = heatmapLevel(day.Words, longest)
+			}
+			hm.Cells = append(hm.Cells, cell)
+			hm.Width = x + heatmapSize
+		}
+		if !hasDays {
+			continue
+		}
+		hm.Height = heatmapTop + 6*heatmapStep + heatmapSize
+		years = append(years, hm)
+	}
+	return years
+}
+
+// heatmapLevel returns the intensity level of a day with sections of the
+// given length, from 1 up to heatmapLevels for the longest day.
+func heatmapLevel(words, longest int) int {
+	if longest == 0 {
+		return 1
+	}
+	return max(1, (words*heatmapLevels+longest-1)/longest)
+}
+
 // ogImageURL returns the absolute URL for OG image, preferring OGImage with Logo fallback.
 func ogImageURL(site model.Site) string {
 	if site.OGImage != "" {
@@ -150,6 +320,37 @@ func ogImageURL(site model.Site) string {
 	return ""
 }
 
+// canonicalURL returns the absolute canonical URL of page: its frontmatter
+// canonical, resolved against the site, or else fallback.
+func canonicalURL(site model.Site, page model.Page, fallback string) string {
+	if page.Canonical == "" {
+		return fallback
+	}
+	return siteURL(site, page.Path, page.Canonical)
+}
+
+// pageImageURL returns the absolute URL of the social card image of page,
+// and whether it is the page's own frontmatter image rather than the site's.
+func pageImageURL(site model.Site, page model.Page) (string, bool) {
+	if page.Image == "" {
+		return ogImageURL(site), false
+	}
+	return siteURL(site, page.Path, page.Image), true
+}
+
+// siteURL resolves ref to an absolute URL: absolute URLs are kept,
+// root-relative ones are prefixed with the base URL and others are relative
+// to the page at pagePath.
+func siteURL(site model.Site, pagePath, ref string) string {
+	if u, err := url.Parse(ref); err == nil && u.IsAbs() {
+		return ref
+	}
+	if strings.HasPrefix(ref, "/") {
+		return site.BaseURL + ref
+	}
+	return site.BaseURL + pagePath + ref
+}
+
 // RenderBlogList renders the blog listing page with all posts.
 func (r *Renderer) RenderBlogList(site model.Site, posts []model.Post) (string, error) {
 	items := make([]blogPostItem, len(posts))
@@ -159,6 +360,11 @@ func (r *Renderer) RenderBlogList(site model.Site, posts []model.Post) (string,
 			Slug:          p.Slug,
 			DateFormatted: p.Date.Format("2006-01-02"),
 			WordCount:     p.WordCount,
+			ReadingTime:   p.ReadingTime,
+			Summary:       p.Summary,
+		}
+		if p.Excerpt != "" {
+			items[i].Excerpt = template.HTML(absoluteURLs(p.Excerpt, "/blog/"+p.Slug+"/"))
 		}
 	}
 
@@ -170,8 +376,15 @@ func (r *Renderer) RenderBlogList(site model.Site, posts []model.Post) (string,
 		IsPost:       false,
 		OGImage:      ogImageURL(site),
 		Version:      r.version,
+		SectionFeeds: sectionFeeds(site, "/blog/"),
 		Posts:        items,
 	}
+	data.JSONLD = pageStructuredData(site, "/blog/", ldWebPage{
+		Type:        "CollectionPage",
+		Name:        "Blog",
+		URL:         data.CanonicalURL,
+		Description: data.Summary,
+	})
 
 	var buf bytes.Buffer
 	if err := r.templates.ExecuteTemplate(&buf, "blog_list.html", data); err != nil {
@@ -182,24 +395,30 @@ func (r *Renderer) RenderBlogList(site model.Site, posts []model.Post) (string,
 
 // RenderBlogPost renders a single blog post.
 func (r *Renderer) RenderBlogPost(site model.Site, post model.Post) (string, error) {
-	summary := post.Summary
-	if summary == "" {
-		summary = site.Description
-	}
+	image, large := pageImageURL(site, post.Page)
 	data := blogPostData{
-		Site:          site,
-		PageTitle:     post.Title,
-		CanonicalURL:  site.BaseURL + "/blog/" + post.Slug + "/",
-		Summary:       summary,
-		IsPost:        true,
-		OGImage:       ogImageURL(site),
-		Version:       r.version,
-		DatePublished: post.Date.Format("2006-01-02"),
+		Site:         site,
+		PageTitle:    post.Title,
+		CanonicalURL: canonicalURL(site, post.Page, site.BaseURL+"/blog/"+post.Slug+"/"),
+		Summary:      cmp.Or(post.Description, post.Summary, site.Description),
+		IsPost:       true,
+		OGImage:      image,
+		NoIndex:      post.NoIndex,
+		LargeCard:    large,
+		Version:      r.version,
+		SectionFeeds: tagFeeds(site, post),
+		MarkdownURL:  post.MarkdownPath,
 	}
+	data.JSONLD = postStructuredData(site, post, data.CanonicalURL, data.Summary, data.OGImage)
 	data.Post.Title = post.Title
 	data.Post.DateFormatted = post.Date.Format("2006-01-02")
 	data.Post.Content = template.HTML(post.Content)
 	data.Post.WordCount = post.WordCount
+	data.Post.ReadingTime = post.ReadingTime
+	data.Post.Backlinks = post.Backlinks
+	data.Post.Audio = post.Audio
+	data.Post.Video = post.Video
+	data.Post.Topics = post.Topics
 
 	var buf bytes.Buffer
 	if err := r.templates.ExecuteTemplate(&buf, "blog_post.html", data); err != nil {
@@ -208,6 +427,264 @@ func (r *Renderer) RenderBlogPost(site model.Site, post model.Post) (string, err
 	return buf.String(), nil
 }
 
+// onThisDayEntry represents a date section in the "on this day" list.
+type onThisDayEntry struct {
+	Year    int
+	Title   string
+	URL     string
+	Content template.HTML
+}
+
+// onThisDayData holds data for "on this day" template rendering.
+type onThisDayData struct {
+	Site         model.Site
+	PageTitle    string
+	CanonicalURL string
+	Summary      string
+	IsPost       bool
+	OGImage      string
+	NoIndex      bool
+	LargeCard    bool
+	Version      string
+	SectionFeeds []model.FeedLink
+	JSONLD       []template.JS
+	MarkdownURL  string
+	PagePath     string
+	PageName     string
+	Day          string // Month and day, e.g. "March 14"
+	MonthDay     string // Month and day in MM-DD format, e.g. "03-14"
+	Year         int
+	Entries      []onThisDayEntry
+}
+
+// RenderOnThisDay renders the "on this day" page of page, listing sections
+// from earlier years dated on the month and day of today. A script refreshes
+// the list from the page's date index when viewed on another day.
+func (r *Renderer) RenderOnThisDay(site model.Site, page model.Page, sections []model.DateSection, today time.Time) (string, error) {
+	data := onThisDayData{
+		Site:         site,
+		PageTitle:    "On this day - " + page.Title,
+		CanonicalURL: site.BaseURL + page.OnThisDayPath,
+		Summary:      site.Description,
+		OGImage:      ogImageURL(site),
+		Version:      r.version,
+		PagePath:     page.Path,
+		PageName:     page.Title,
+		Day:          today.Format("January 2"),
+		MonthDay:     today.Format("01-02"),
+		Year:         today.Year(),
+	}
+	data.JSONLD = pageStructuredData(site, page.OnThisDayPath, ldWebPage{
+		Type:        "CollectionPage",
+		Name:        data.PageTitle,
+		URL:         data.CanonicalURL,
+		Description: data.Summary,
+	})
+	for _, s := range model.OnThisDay(sections, today) {
+		data.Entries = append(data.Entries, onThisDayEntry{
+			Year:    s.Date.Year(),
+			Title:   s.Title,
+			URL:     s.URL(),
+			Content: template.HTML(s.Content),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := r.templates.ExecuteTemplate(&buf, "on_this_day.html", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// topicMention is a date section listed on a topic page.
+type topicMention struct {
+	Date    string
+	Title   string
+	URL     string
+	Snippet template.HTML // Snippet with the topic word highlighted
+}
+
+// topicData holds data for topic page template rendering.
+type topicData struct {
+	Site         model.Site
+	PageTitle    string
+	CanonicalURL string
+	Summary      string
+	IsPost       bool
+	OGImage      string
+	NoIndex      bool
+	LargeCard    bool
+	Version      string
+	SectionFeeds []model.FeedLink
+	JSONLD       []template.JS
+	MarkdownURL  string
+	PagePath     string
+	PageName     string
+	Word         string
+	Count        int
+	Mentions     []topicMention
+}
+
+// RenderTopic renders the topic page of topic on page, listing the date
+// sections that mention it with their snippets.
+func (r *Renderer) RenderTopic(site model.Site, page model.Page, topic model.Topic, mentions []model.TopicMention) (string, error) {
+	data := topicData{
+		Site:         site,
+		PageTitle:    topic.Word + " - " + page.Title,
+		CanonicalURL: site.BaseURL + topic.Path,
+		Summary:      site.Description,
+		OGImage:      ogImageURL(site),
+		Version:      r.version,
+		PagePath:     page.Path,
+		PageName:     page.Title,
+		Word:         topic.Word,
+		Count:        topic.Count,
+	}
+	data.JSONLD = pageStructuredData(site, topic.Path, ldWebPage{
+		Type:        "CollectionPage",
+		Name:        topic.Word,
+		URL:         data.CanonicalURL,
+		Description: data.Summary,
+	})
+	for _, m := range mentions {
+		date := m.Section.Date.Format("January 2, 2006")
+		if m.Section.HasTime {
+			date += " " + m.Section.Date.Format("15:04")
+		}
+		data.Mentions = append(data.Mentions, topicMention{
+			Date:    date,
+			Title:   m.Section.Title,
+			URL:     m.Section.URL(),
+			Snippet: highlight(m.Snippet, topic.Words()),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := r.templates.ExecuteTemplate(&buf, "topic.html", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// topicCloudWeights is the number of font weights in the topic cloud.
+const topicCloudWeights = 5
+
+// topicIndexEntry is a topic of the site-wide topic index.
+type topicIndexEntry struct {
+	Word     string
+	Slug     string
+	Count    int
+	Weight   int // Cloud size from 1 to topicCloudWeights by count
+	Posts    []topicIndexLink
+	Sections []topicIndexLink
+}
+
+// topicIndexLink links a topic to a post or date section mentioning it.
+type topicIndexLink struct {
+	Date  string
+	Title string
+	URL   string
+}
+
+// topicIndexData holds data for topic index template rendering.
+type topicIndexData struct {
+	Site         model.Site
+	PageTitle    string
+	CanonicalURL string
+	Summary      string
+	IsPost       bool
+	OGImage      string
+	NoIndex      bool
+	LargeCard    bool
+	Version      string
+	SectionFeeds []model.FeedLink
+	JSONLD       []template.JS
+	MarkdownURL  string
+	Topics       []topicIndexEntry
+}
+
+// RenderTopicIndex renders the site-wide topic index: a cloud of topics
+// sized by count, and the posts and date sections of each topic.
+func (r *Renderer) RenderTopicIndex(site model.Site, topics []model.SiteTopic) (string, error) {
+	data := topicIndexData{
+		Site:         site,
+		PageTitle:    "Topics",
+		CanonicalURL: site.BaseURL + model.TopicIndexPath,
+		Summary:      site.Description,
+		OGImage:      ogImageURL(site),
+		Version:      r.version,
+	}
+	data.JSONLD = pageStructuredData(site, model.TopicIndexPath, ldWebPage{
+		Type:        "CollectionPage",
+		Name:        data.PageTitle,
+		URL:         data.CanonicalURL,
+		Description: data.Summary,
+	})
+
+	lowest, highest := 0, 0
+	for i, t := range topics {
+		if i == 0 || t.Count < lowest {
+			lowest = t.Count
+		}
+		highest = max(highest, t.Count)
+	}
+	for _, t := range topics {
+		entry := topicIndexEntry{
+			Word:   t.Word,
+			Slug:   t.Slug,
+			Count:  t.Count,
+			Weight: cloudWeight(t.Count, lowest, highest),
+		}
+		for _, p := range t.Posts {
+			entry.Posts = append(entry.Posts, topicIndexLink{
+				Date:  p.Date.Format("2006-01-02"),
+				Title: p.Title,
+				URL:   p.Path,
+			})
+		}
+		for _, s := range t.Sections {
+			entry.Sections = append(entry.Sections, topicIndexLink{
+				Date:  s.Date.Format("2006-01-02"),
+				Title: cmp.Or(s.Title, s.PageTitle),
+				URL:   s.URL(),
+			})
+		}
+		data.Topics = append(data.Topics, entry)
+	}
+
+	var buf bytes.Buffer
+	if err := r.templates.ExecuteTemplate(&buf, "topic_index.html", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// cloudWeight returns the cloud size of a topic with count occurrences, on a
+// logarithmic scale from 1 for the lowest count to topicCloudWeights for the
+// highest.
+func cloudWeight(count, lowest, highest int) int {
+	if highest <= lowest {
+		return (topicCloudWeights + 1) / 2
+	}
+	scale := math.Log(float64(count)/float64(lowest)) / math.Log(float64(highest)/float64(lowest))
+	return 1 + int(math.Round(scale*(topicCloudWeights-1)))
+}
+
+// highlight escapes text and wraps the mentions of words in <mark> elements.
+func highlight(text string, words []string) template.HTML {
+	var b strings.Builder
+	last := 0
+	for _, m := range topics.Mentions(text, words) {
+		b.WriteString(template.HTMLEscapeString(text[last:m[0]]))
+		b.WriteString("<mark>")
+		b.WriteString(template.HTMLEscapeString(text[m[0]:m[1]]))
+		b.WriteString("</mark>")
+		last = m[1]
+	}
+	b.WriteString(template.HTMLEscapeString(text[last:]))
+	return template.HTML(b.String())
+}
+
 // notFoundData holds data for 404 page template rendering.
 type notFoundData struct {
 	Site         model.Site
@@ -216,7 +693,12 @@ type notFoundData struct {
 	Summary      string
 	IsPost       bool
 	OGImage      string
+	NoIndex      bool
+	LargeCard    bool
 	Version      string
+	SectionFeeds []model.FeedLink
+	JSONLD       []template.JS
+	MarkdownURL  string
 }
 
 // Render404 renders the 404 error page.
@@ -227,6 +709,7 @@ func (r *Renderer) Render404(site model.Site) (string, error) {
 		CanonicalURL: site.BaseURL + "/404/",
 		Summary:      site.Description,
 		Version:      r.version,
+		JSONLD:       siteStructuredData(site),
 	}
 
 	var buf bytes.Buffer
@@ -238,19 +721,58 @@ func (r *Renderer) Render404(site model.Site) (string, error) {
 
 // rssChannel represents the RSS channel element.
 type rssChannel struct {
-	Title         string    `xml:"title"`
-	Link          string    `xml:"link"`
-	Description   string    `xml:"description"`
-	LastBuildDate string    `xml:"lastBuildDate"`
-	Items         []rssItem `xml:"item"`
+	Title          string          `xml:"title"`
+	Link           string          `xml:"link"`
+	Description    string          `xml:"description"`
+	AtomLink       atomLink        `xml:"atom:link"`
+	LastBuildDate  string          `xml:"lastBuildDate"`
+	ItunesAuthor   string          `xml:"itunes:author,omitempty"`
+	ItunesOwner    *itunesOwner    `xml:"itunes:owner,omitempty"`
+	ItunesImage    *itunesImage    `xml:"itunes:image,omitempty"`
+	ItunesCategory *itunesCategory `xml:"itunes:category,omitempty"`
+	ItunesExplicit string          `xml:"itunes:explicit,omitempty"`
+	ItunesType     string          `xml:"itunes:type,omitempty"`
+	Items          []rssItem       `xml:"item"`
 }
 
 // rssItem represents an RSS item element.
 type rssItem struct {
-	Title       string   `xml:"title"`
-	Link        string   `xml:"link"`
-	Description rssCDATA `xml:"description"`
-	PubDate     string   `xml:"pubDate"`
+	Title          string        `xml:"title"`
+	Link           string        `xml:"link"`
+	Description    rssCDATA      `xml:"description"`
+	Creator        string        `xml:"dc:creator,omitempty"`
+	Categories     []string      `xml:"category"`
+	GUID           rssGUID       `xml:"guid"`
+	PubDate        string        `xml:"pubDate"`
+	Enclosure      *rssEnclosure `xml:"enclosure,omitempty"`
+	ItunesAuthor   string        `xml:"itunes:author,omitempty"`
+	ItunesDuration string        `xml:"itunes:duration,omitempty"`
+	ItunesEpisode  int           `xml:"itunes:episode,omitempty"`
+	ItunesExplicit string        `xml:"itunes:explicit,omitempty"`
+}
+
+// rssEnclosure represents an RSS enclosure element for a media file.
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// itunesOwner represents the iTunes podcast owner element.
+type itunesOwner struct {
+	Name  string `xml:"itunes:name"`
+	Email string `xml:"itunes:email,omitempty"`
+}
+
+// itunesImage represents the iTunes podcast artwork element.
+type itunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+// itunesCategory represents an iTunes category, optionally with a subcategory.
+type itunesCategory struct {
+	Text        string          `xml:"text,attr"`
+	Subcategory *itunesCategory `xml:"itunes:category,omitempty"`
 }
 
 // rssCDATA wraps content in CDATA.
@@ -258,46 +780,88 @@ type rssCDATA struct {
 	Content string `xml:",cdata"`
 }
 
+// rssGUID represents an RSS guid element. IsPermaLink is "true" when the
+// GUID is the item link.
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
 // rssFeed represents the root RSS element.
 type rssFeed struct {
-	XMLName xml.Name   `xml:"rss"`
-	Version string     `xml:"version,attr"`
-	Channel rssChannel `xml:"channel"`
+	XMLName     xml.Name   `xml:"rss"`
+	Version     string     `xml:"version,attr"`
+	XmlnsAtom   string     `xml:"xmlns:atom,attr"`
+	XmlnsDC     string     `xml:"xmlns:dc,attr"`
+	XmlnsItunes string     `xml:"xmlns:itunes,attr,omitempty"`
+	Channel     rssChannel `xml:"channel"`
 }
 
 // RenderFeed renders an RSS 2.0 feed from FeedItems.
 func (r *Renderer) RenderFeed(site model.Site, items []model.FeedItem) (string, error) {
+	return r.renderRSS(site, site.Title, site.BaseURL, site.BaseURL+"/feed.xml", items)
+}
+
+// RenderSectionFeed renders the RSS 2.0 feed of a site section, such as the
+// blog, a tag or a feed page, described by link.
+func (r *Renderer) RenderSectionFeed(site model.Site, link model.FeedLink, items []model.FeedItem) (string, error) {
+	sectionURL := site.BaseURL + strings.TrimSuffix(link.Href, model.SectionFeedFile)
+	return r.renderRSS(site, site.Title+" - "+link.Title, sectionURL, site.BaseURL+link.Href, items)
+}
+
+// renderRSS renders an RSS 2.0 feed with the given channel title, link and
+// self link.
+func (r *Renderer) renderRSS(site model.Site, title, link, self string, items []model.FeedItem) (string, error) {
 	if len(items) == 0 {
 		return "", nil
 	}
 
-	// Limit to 20 items
-	feedItems := items
-	if len(feedItems) > 20 {
-		feedItems = feedItems[:20]
-	}
+	feedItems := limitFeedItems(site, items)
 
 	// Build RSS items
 	rssItems := make([]rssItem, len(feedItems))
 	for i, item := range feedItems {
+		description := feedContent(site, item)
+		if summaryOnly(site, item) {
+			description = template.HTMLEscapeString(item.FeedSummary())
+		}
 		rssItems[i] = rssItem{
 			Title:       item.FeedTitle(),
 			Link:        item.FeedLink(),
-			Description: rssCDATA{Content: item.FeedContent()},
+			Description: rssCDATA{Content: description},
+			Creator:     itemAuthor(site, item),
+			Categories:  item.FeedCategories(),
+			GUID:        rssGUID{IsPermaLink: strconv.FormatBool(item.FeedGUID() == item.FeedLink()), Value: item.FeedGUID()},
 			PubDate:     item.FeedDate().Format(time.RFC1123Z),
 		}
+		if media := item.FeedMedia(); media != nil {
+			rssItems[i].Enclosure = &rssEnclosure{URL: media.URL, Length: media.Length, Type: media.Type}
+			if site.Podcast != nil {
+				rssItems[i].ItunesAuthor = itemAuthor(site, item)
+				rssItems[i].ItunesDuration = media.Duration
+				rssItems[i].ItunesEpisode = media.Episode
+				rssItems[i].ItunesExplicit = strconv.FormatBool(site.Podcast.Explicit)
+			}
+		}
 	}
 
 	feed := rssFeed{
-		Version: "2.0",
+		Version:   "2.0",
+		XmlnsAtom: "http://www.w3.org/2005/Atom",
+		XmlnsDC:   "http://purl.org/dc/elements/1.1/",
 		Channel: rssChannel{
-			Title:         site.Title,
-			Link:          site.BaseURL,
+			Title:         title,
+			Link:          link,
 			Description:   site.Description,
+			AtomLink:      atomLink{Rel: "self", Type: "application/rss+xml", Href: self},
 			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
 			Items:         rssItems,
 		},
 	}
+	if site.Podcast != nil {
+		feed.XmlnsItunes = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+		addPodcastTags(&feed.Channel, site)
+	}
 
 	var buf bytes.Buffer
 	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
@@ -310,3 +874,358 @@ func (r *Renderer) RenderFeed(site model.Site, items []model.FeedItem) (string,
 
 	return buf.String(), nil
 }
+
+// addPodcastTags adds the site's iTunes podcast metadata to an RSS channel.
+func addPodcastTags(channel *rssChannel, site model.Site) {
+	podcast := site.Podcast
+	channel.ItunesAuthor = podcast.Author
+	if channel.ItunesAuthor == "" {
+		channel.ItunesAuthor = feedAuthor(site)
+	}
+	if podcast.Email != "" {
+		channel.ItunesOwner = &itunesOwner{Name: channel.ItunesAuthor, Email: podcast.Email}
+	}
+	if podcast.Image != "" {
+		href := podcast.Image
+		if strings.HasPrefix(href, "/") {
+			href = site.BaseURL + href
+		}
+		channel.ItunesImage = &itunesImage{Href: href}
+	}
+	if podcast.Category != "" {
+		channel.ItunesCategory = &itunesCategory{Text: podcast.Category}
+		if podcast.Subcategory != "" {
+			channel.ItunesCategory.Subcategory = &itunesCategory{Text: podcast.Subcategory}
+		}
+	}
+	channel.ItunesExplicit = strconv.FormatBool(podcast.Explicit)
+	channel.ItunesType = podcast.Type
+}
+
+// absoluteURLs resolves relative src and href attributes in HTML content
+// against base, so feed readers can load images and follow links.
+// Content that cannot be tokenized is returned unchanged.
+func absoluteURLs(content, base string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return content
+	}
+
+	var buf strings.Builder
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				return content
+			}
+			return buf.String()
+		}
+		raw := string(z.Raw())
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			buf.WriteString(raw)
+			continue
+		}
+
+		token := z.Token()
+		changed := false
+		for i, attr := range token.Attr {
+			if attr.Namespace != "" || attr.Key != "src" && attr.Key != "href" {
+				continue
+			}
+			ref, err := url.Parse(strings.TrimSpace(attr.Val))
+			if err != nil || ref.IsAbs() {
+				continue
+			}
+			token.Attr[i].Val = baseURL.ResolveReference(ref).String()
+			changed = true
+		}
+		if changed {
+			buf.WriteString(token.String())
+		} else {
+			buf.WriteString(raw)
+		}
+	}
+}
+
+// limitFeedItems returns at most the site's feed item limit of items.
+func limitFeedItems(site model.Site, items []model.FeedItem) []model.FeedItem {
+	if limit := site.FeedItemLimit(); len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// feedContent returns the HTML content of item for feeds, with relative URLs
+// made absolute: its excerpt and a link to the full item when excerpts are
+// published and item has one, or else its full content.
+func feedContent(site model.Site, item model.FeedItem) string {
+	content := item.FeedContent()
+	if excerpt := item.FeedExcerpt(); site.FeedExcerpts && excerpt != "" {
+		content = excerpt + `<p><a href="` + template.HTMLEscapeString(item.FeedLink()) + `">Read more…</a></p>`
+	}
+	return absoluteURLs(content, item.FeedLink())
+}
+
+// summaryOnly reports whether item is published as its summary rather than
+// its full content. Items without a summary always publish full content.
+func summaryOnly(site model.Site, item model.FeedItem) bool {
+	return site.FeedSummaryOnly && item.FeedSummary() != ""
+}
+
+// feedUpdated returns the newest date among feed items.
+func feedUpdated(items []model.FeedItem) time.Time {
+	var updated time.Time
+	for _, item := range items {
+		if item.FeedDate().After(updated) {
+			updated = item.FeedDate()
+		}
+	}
+	return updated
+}
+
+// feedAuthor returns the author name for feeds, falling back to the site title.
+func feedAuthor(site model.Site) string {
+	if site.Author != "" {
+		return site.Author
+	}
+	return site.Title
+}
+
+// itemAuthor returns the author of a feed item, falling back to the site author.
+func itemAuthor(site model.Site, item model.FeedItem) string {
+	if author := item.FeedAuthor(); author != "" {
+		return author
+	}
+	return site.Author
+}
+
+// atomFeed represents the root Atom feed element.
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Links    []atomLink  `xml:"link"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Author   atomPerson  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+// atomLink represents an Atom link element.
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+}
+
+// atomPerson represents an Atom author element.
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+// atomEntry represents an Atom entry element.
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	ID         string         `xml:"id"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+// atomCategory represents an Atom category element.
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// atomText holds Atom text or escaped HTML content.
+type atomText struct {
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// RenderAtomFeed renders an Atom 1.0 feed from FeedItems.
+// Entry IDs are the items' GUIDs, and the feed's updated time is the newest item date.
+func (r *Renderer) RenderAtomFeed(site model.Site, items []model.FeedItem) (string, error) {
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	feedItems := limitFeedItems(site, items)
+
+	entries := make([]atomEntry, len(feedItems))
+	for i, item := range feedItems {
+		date := item.FeedDate().UTC().Format(time.RFC3339)
+		entry := atomEntry{
+			Title:     item.FeedTitle(),
+			Links:     []atomLink{{Rel: "alternate", Type: "text/html", Href: item.FeedLink()}},
+			ID:        item.FeedGUID(),
+			Published: date,
+			Updated:   date,
+		}
+		if media := item.FeedMedia(); media != nil {
+			entry.Links = append(entry.Links, atomLink{Rel: "enclosure", Type: media.Type, Length: media.Length, Href: media.URL})
+		}
+		if author := item.FeedAuthor(); author != "" {
+			entry.Author = &atomPerson{Name: author}
+		}
+		for _, category := range item.FeedCategories() {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		if summary := item.FeedSummary(); summary != "" {
+			entry.Summary = &atomText{Type: "text", Content: summary}
+		}
+		if !summaryOnly(site, item) {
+			entry.Content = &atomText{Type: "html", Content: feedContent(site, item)}
+		}
+		entries[i] = entry
+	}
+
+	feed := atomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		Title:    site.Title,
+		Subtitle: site.Description,
+		Links: []atomLink{
+			{Rel: "alternate", Type: "text/html", Href: site.BaseURL + "/"},
+			{Rel: "self", Type: "application/atom+xml", Href: site.BaseURL + "/atom.xml"},
+		},
+		ID:      site.BaseURL + "/",
+		Updated: feedUpdated(feedItems).UTC().Format(time.RFC3339),
+		Author:  atomPerson{Name: feedAuthor(site)},
+		Entries: entries,
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// jsonFeed represents a JSON Feed 1.1 document.
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url"`
+	FeedURL     string           `json:"feed_url"`
+	Description string           `json:"description,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+// jsonFeedAuthor represents a JSON Feed author object.
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// jsonFeedItem represents a JSON Feed item.
+type jsonFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url"`
+	Title         string               `json:"title"`
+	ContentHTML   string               `json:"content_html,omitempty"`
+	ContentText   string               `json:"content_text,omitempty"`
+	Summary       string               `json:"summary,omitempty"`
+	DatePublished string               `json:"date_published"`
+	DateModified  string               `json:"date_modified"`
+	Authors       []jsonFeedAuthor     `json:"authors,omitempty"`
+	Tags          []string             `json:"tags,omitempty"`
+	Attachments   []jsonFeedAttachment `json:"attachments,omitempty"`
+}
+
+// jsonFeedAttachment represents a JSON Feed attachment for a media file.
+type jsonFeedAttachment struct {
+	URL               string `json:"url"`
+	MimeType          string `json:"mime_type"`
+	SizeInBytes       int64  `json:"size_in_bytes,omitempty"`
+	DurationInSeconds int    `json:"duration_in_seconds,omitempty"`
+}
+
+// RenderJSONFeed renders a JSON Feed 1.1 document from FeedItems.
+// Item IDs are the items' GUIDs.
+func (r *Renderer) RenderJSONFeed(site model.Site, items []model.FeedItem) (string, error) {
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	feedItems := limitFeedItems(site, items)
+
+	jsonItems := make([]jsonFeedItem, len(feedItems))
+	for i, item := range feedItems {
+		date := item.FeedDate().UTC().Format(time.RFC3339)
+		jsonItem := jsonFeedItem{
+			ID:            item.FeedGUID(),
+			URL:           item.FeedLink(),
+			Title:         item.FeedTitle(),
+			Summary:       item.FeedSummary(),
+			DatePublished: date,
+			DateModified:  date,
+			Tags:          item.FeedCategories(),
+		}
+		if summaryOnly(site, item) {
+			jsonItem.ContentText = item.FeedSummary()
+		} else {
+			jsonItem.ContentHTML = feedContent(site, item)
+		}
+		if media := item.FeedMedia(); media != nil {
+			jsonItem.Attachments = []jsonFeedAttachment{{
+				URL:               media.URL,
+				MimeType:          media.Type,
+				SizeInBytes:       media.Length,
+				DurationInSeconds: durationSeconds(media.Duration),
+			}}
+		}
+		if author := item.FeedAuthor(); author != "" {
+			jsonItem.Authors = []jsonFeedAuthor{{Name: author}}
+		}
+		jsonItems[i] = jsonItem
+	}
+
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       site.Title,
+		HomePageURL: site.BaseURL + "/",
+		FeedURL:     site.BaseURL + "/feed.json",
+		Description: site.Description,
+		Authors:     []jsonFeedAuthor{{Name: feedAuthor(site)}},
+		Items:       jsonItems,
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// durationSeconds converts a duration such as "1:02:03", "32:15" or "95" to
+// seconds. It returns zero when the duration cannot be parsed.
+func durationSeconds(duration string) int {
+	if duration == "" {
+		return 0
+	}
+	seconds := 0
+	for _, part := range strings.Split(duration, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds
+}
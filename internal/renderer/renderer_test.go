@@ -1,6 +1,7 @@
 package renderer
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -204,6 +205,46 @@ func TestRenderBlogList(t *testing.T) {
 	}
 }
 
+func TestRenderBlogList_SummariesAndExcerpts(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site"}
+	posts := []model.Post{
+		{
+			Page:    model.Page{Title: "Rich", Slug: "rich"},
+			Date:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Summary: "Plain summary of rich",
+			Excerpt: `<p>Rich <em>intro</em> <img src="assets/a.png"></p>`,
+		},
+		{
+			Page:    model.Page{Title: "Plain", Slug: "plain"},
+			Date:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Summary: "Plain summary",
+		},
+	}
+
+	got, err := r.RenderBlogList(site, posts)
+	if err != nil {
+		t.Fatalf("RenderBlogList() error = %v", err)
+	}
+	for _, want := range []string{
+		`<div class="excerpt"><p>Rich <em>intro</em> <img src="/blog/rich/assets/a.png"></p><a class="read-more" href="/blog/rich/">Read more…</a></div>`,
+		`<p class="summary">Plain summary</p>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderBlogList() missing %q", want)
+		}
+	}
+	if strings.Contains(got, "Plain summary of rich") {
+		t.Error("RenderBlogList() should show the excerpt instead of the summary")
+	}
+}
+
 func TestRenderBlogPost(t *testing.T) {
 	t.Parallel()
 
@@ -585,6 +626,52 @@ func TestRenderBlogPost_WordCount(t *testing.T) {
 	}
 }
 
+func TestRenderBlogPost_ReadingTime(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+	post := model.Post{
+		Page:        model.Page{Title: "Test Post", Slug: "test-post"},
+		Date:        time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		WordCount:   1000,
+		ReadingTime: 5,
+	}
+
+	got, err := r.RenderBlogPost(site, post)
+	if err != nil {
+		t.Fatalf("RenderBlogPost() error = %v", err)
+	}
+	for _, want := range []string{
+		`<span class="reading-time"> · 5 min read</span>`,
+		`"timeRequired": "PT5M"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderBlogPost() missing %q", want)
+		}
+	}
+
+	list, err := r.RenderBlogList(site, []model.Post{post})
+	if err != nil {
+		t.Fatalf("RenderBlogList() error = %v", err)
+	}
+	if !strings.Contains(list, "1000 words<span class=\"reading-time\"> · 5 min read</span>") {
+		t.Error("RenderBlogList() should show the reading time")
+	}
+
+	post.ReadingTime = 0
+	got, err = r.RenderBlogPost(site, post)
+	if err != nil {
+		t.Fatalf("RenderBlogPost() error = %v", err)
+	}
+	if strings.Contains(got, "timeRequired") || strings.Contains(got, "min read") {
+		t.Error("RenderBlogPost() without reading time should omit it")
+	}
+}
+
 func TestRenderBase_WithoutFavicon(t *testing.T) {
 	t.Parallel()
 
@@ -682,7 +769,7 @@ func TestRenderFeed_ValidRSSStructure(t *testing.T) {
 	}
 
 	// Check RSS root element
-	if !strings.Contains(got, `<rss version="2.0">`) {
+	if !strings.Contains(got, `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/">`) {
 		t.Error("RenderFeed() missing RSS root element")
 	}
 
@@ -1334,6 +1421,159 @@ func TestRenderPage_OpenGraphTags(t *testing.T) {
 	}
 }
 
+func TestRenderPage_SEOFrontmatter(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	site := model.Site{
+		Title:       "Test Site",
+		Description: "Site description",
+		BaseURL:     "https://example.com",
+		OGImage:     "/og.png",
+	}
+
+	tests := []struct {
+		name    string
+		render  func() (string, error)
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "page defaults",
+			render: func() (string, error) {
+				return r.RenderPage(site, model.Page{Title: "About", Slug: "about", Path: "/about/"})
+			},
+			want: []string{
+				`<meta name="description" content="Site description">`,
+				`<link rel="canonical" href="https://example.com/about/">`,
+				`<meta property="og:image" content="https://example.com/og.png">`,
+				`<meta name="twitter:card" content="summary">`,
+			},
+			notWant: []string{`name="robots"`},
+		},
+		{
+			name: "page frontmatter",
+			render: func() (string, error) {
+				return r.RenderPage(site, model.Page{
+					Title:       "About",
+					Slug:        "about",
+					Path:        "/about/",
+					Description: "All about us",
+					Canonical:   "https://other.example/about/",
+					NoIndex:     true,
+					Image:       "cover.png",
+				})
+			},
+			want: []string{
+				`<meta name="description" content="All about us">`,
+				`<link rel="canonical" href="https://other.example/about/">`,
+				`<meta property="og:url" content="https://other.example/about/">`,
+				`<meta name="robots" content="noindex">`,
+				`<meta property="og:image" content="https://example.com/about/cover.png">`,
+				`<meta name="twitter:image" content="https://example.com/about/cover.png">`,
+				`<meta name="twitter:card" content="summary_large_image">`,
+			},
+		},
+		{
+			name: "post frontmatter",
+			render: func() (string, error) {
+				return r.RenderBlogPost(site, model.Post{
+					Page: model.Page{
+						Title:       "Post",
+						Slug:        "post",
+						Path:        "/blog/post/",
+						Description: "Post description",
+						Canonical:   "/blog/original/",
+						Image:       "assets/cover.jpg",
+					},
+					Summary: "Derived summary",
+				})
+			},
+			want: []string{
+				`<meta name="description" content="Post description">`,
+				`<link rel="canonical" href="https://example.com/blog/original/">`,
+				`<meta property="og:image" content="https://example.com/blog/post/assets/cover.jpg">`,
+				`<meta name="twitter:card" content="summary_large_image">`,
+			},
+			notWant: []string{"Derived summary", `name="robots"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.render()
+			if err != nil {
+				t.Fatalf("render error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("missing %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("should not contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestRender_MarkdownAlternate(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+
+	tests := []struct {
+		name   string
+		render func() (string, error)
+		want   bool
+	}{
+		{
+			name: "page with markdown copy",
+			render: func() (string, error) {
+				return r.RenderPage(site, model.Page{Title: "About", Slug: "about", Path: "/about/", MarkdownPath: "/about/index.md"})
+			},
+			want: true,
+		},
+		{
+			name: "page without markdown copy",
+			render: func() (string, error) {
+				return r.RenderPage(site, model.Page{Title: "About", Slug: "about", Path: "/about/"})
+			},
+		},
+		{
+			name: "post with markdown copy",
+			render: func() (string, error) {
+				return r.RenderBlogPost(site, model.Post{Page: model.Page{Title: "Post", Slug: "post", MarkdownPath: "/about/index.md"}})
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.render()
+			if err != nil {
+				t.Fatalf("render error = %v", err)
+			}
+			link := `<link rel="alternate" type="text/markdown" href="/about/index.md">`
+			if strings.Contains(got, link) != tt.want {
+				t.Errorf("markdown alternate link present = %v, want %v", !tt.want, tt.want)
+			}
+		})
+	}
+}
+
 func TestRenderBlogPost_TwitterCardTags(t *testing.T) {
 	t.Parallel()
 
@@ -1420,7 +1660,7 @@ func TestRenderPage_TwitterCardTags(t *testing.T) {
 	}
 }
 
-func TestRenderPage_JSONLDNoArticleSchema(t *testing.T) {
+func TestRenderPage_JSONLDWebPageSchema(t *testing.T) {
 	t.Parallel()
 
 	r, err := New()
@@ -1449,13 +1689,16 @@ func TestRenderPage_JSONLDNoArticleSchema(t *testing.T) {
 		t.Error("RenderPage() missing WebSite @type in JSON-LD")
 	}
 
-	// Regular pages should NOT have Article schema
-	if strings.Contains(got, `"@type": "Article"`) {
-		t.Error("RenderPage() should NOT have Article schema")
+	// Regular pages are a WebPage, not a BlogPosting
+	if !strings.Contains(got, `"@type": "WebPage"`) {
+		t.Error("RenderPage() missing WebPage @type in JSON-LD")
+	}
+	if strings.Contains(got, `"@type": "BlogPosting"`) {
+		t.Error("RenderPage() should NOT have BlogPosting schema")
 	}
 }
 
-func TestRenderBlogPost_JSONLDArticleSchema(t *testing.T) {
+func TestRenderBlogPost_JSONLDBlogPostingSchema(t *testing.T) {
 	t.Parallel()
 
 	r, err := New()
@@ -1489,29 +1732,245 @@ func TestRenderBlogPost_JSONLDArticleSchema(t *testing.T) {
 		t.Error("RenderBlogPost() missing JSON-LD script tag")
 	}
 
-	// Check Article schema type
-	if !strings.Contains(got, `"@type": "Article"`) {
-		t.Error("RenderBlogPost() missing Article @type in JSON-LD")
+	// Check BlogPosting schema type
+	if !strings.Contains(got, `"@type": "BlogPosting"`) {
+		t.Error("RenderBlogPost() missing BlogPosting @type in JSON-LD")
 	}
 
 	// Check headline
 	if !strings.Contains(got, `"headline": "My Test Post"`) {
-		t.Error("RenderBlogPost() missing headline in Article JSON-LD")
+		t.Error("RenderBlogPost() missing headline in BlogPosting JSON-LD")
 	}
 
 	// Check datePublished
 	if !strings.Contains(got, `"datePublished": "2024-01-15"`) {
-		t.Error("RenderBlogPost() missing datePublished in Article JSON-LD")
+		t.Error("RenderBlogPost() missing datePublished in BlogPosting JSON-LD")
 	}
 
 	// Check author
 	if !strings.Contains(got, `"author"`) && !strings.Contains(got, `"Jeroen"`) {
-		t.Error("RenderBlogPost() missing author in Article JSON-LD")
+		t.Error("RenderBlogPost() missing author in BlogPosting JSON-LD")
 	}
 
 	// Check description
 	if !strings.Contains(got, `"description": "This is the post summary"`) {
-		t.Error("RenderBlogPost() missing description in Article JSON-LD")
+		t.Error("RenderBlogPost() missing description in BlogPosting JSON-LD")
+	}
+}
+
+// jsonLDNodes decodes the JSON-LD script elements of a rendered page,
+// keyed by @type.
+func jsonLDNodes(t *testing.T, got string) map[string]map[string]any {
+	t.Helper()
+	nodes := map[string]map[string]any{}
+	const open = `<script type="application/ld+json">`
+	for _, part := range strings.Split(got, open)[1:] {
+		body, _, ok := strings.Cut(part, "</script>")
+		if !ok {
+			t.Fatalf("unterminated JSON-LD script: %q", part)
+		}
+		var node map[string]any
+		if err := json.Unmarshal([]byte(body), &node); err != nil {
+			t.Fatalf("JSON-LD does not parse: %v\n%s", err, body)
+		}
+		nodes[node["@type"].(string)] = node
+	}
+	return nodes
+}
+
+func TestRenderBlogPost_JSONLD(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:   "Quality Shepherd",
+		BaseURL: "https://example.com",
+		Author:  "Jeroen",
+		Logo:    "/logo.png",
+	}
+	post := model.Post{
+		Page: model.Page{
+			Title: `Quotes "and" </script> tags`,
+			Slug:  "quotes",
+			Path:  "/blog/quotes/",
+			Image: "assets/cover.png",
+		},
+		Date:        time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		Summary:     "A summary",
+		WordCount:   1234,
+		ReadingTime: 6,
+		Tags:        []string{"go"},
+	}
+
+	got, err := r.RenderBlogPost(site, post)
+	if err != nil {
+		t.Fatalf("RenderBlogPost() error = %v", err)
+	}
+	if strings.Count(got, "</script>") != strings.Count(got, "<script") {
+		t.Fatal("RenderBlogPost() title ended a script element early")
+	}
+
+	nodes := jsonLDNodes(t, got)
+	posting, ok := nodes["BlogPosting"]
+	if !ok {
+		t.Fatalf("RenderBlogPost() missing BlogPosting, got %v", nodes)
+	}
+	wantPosting := map[string]any{
+		"headline":      post.Title,
+		"url":           "https://example.com/blog/quotes/",
+		"image":         "https://example.com/blog/quotes/assets/cover.png",
+		"datePublished": "2024-01-15",
+		"dateModified":  "2024-01-15",
+		"timeRequired":  "PT6M",
+		"wordCount":     1234.0,
+	}
+	for key, want := range wantPosting {
+		if posting[key] != want {
+			t.Errorf("BlogPosting %s = %v, want %v", key, posting[key], want)
+		}
+	}
+	if author := posting["author"].(map[string]any); author["@type"] != "Person" || author["name"] != "Jeroen" {
+		t.Errorf("BlogPosting author = %v, want Person Jeroen", author)
+	}
+	publisher := posting["publisher"].(map[string]any)
+	if publisher["name"] != "Quality Shepherd" || publisher["logo"].(map[string]any)["url"] != "https://example.com/logo.png" {
+		t.Errorf("BlogPosting publisher = %v", publisher)
+	}
+	if entity := posting["mainEntityOfPage"].(map[string]any); entity["@id"] != "https://example.com/blog/quotes/" {
+		t.Errorf("BlogPosting mainEntityOfPage = %v", entity)
+	}
+
+	crumbs, ok := nodes["BreadcrumbList"]
+	if !ok {
+		t.Fatal("RenderBlogPost() missing BreadcrumbList")
+	}
+	var names []string
+	for _, item := range crumbs["itemListElement"].([]any) {
+		names = append(names, item.(map[string]any)["name"].(string))
+	}
+	if want := []string{"Quality Shepherd", "Blog", post.Title}; strings.Join(names, "|") != strings.Join(want, "|") {
+		t.Errorf("BreadcrumbList names = %q, want %q", names, want)
+	}
+}
+
+func TestPublisherLD_Logo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		logo string
+		want string
+	}{
+		{name: "site-relative logo", logo: "/logo.png", want: "https://example.com/logo.png"},
+		{name: "absolute logo", logo: "https://cdn.example.net/logo.png", want: "https://cdn.example.net/logo.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			site := model.Site{Title: "Test Site", BaseURL: "https://example.com", Logo: tt.logo}
+			org := publisherLD(site)
+			if org.Logo == nil || org.Logo.URL != tt.want {
+				t.Errorf("publisherLD() Logo = %+v, want URL %q", org.Logo, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPage_JSONLDBreadcrumbs(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	moments := model.Page{Title: "Moments", Slug: "moments", Path: "/moments/"}
+	site := model.Site{
+		Title:   "Site",
+		BaseURL: "https://example.com",
+		Pages:   []model.Page{moments},
+	}
+
+	tests := []struct {
+		name      string
+		page      model.Page
+		wantItems []string
+	}{
+		{
+			name: "top-level page has no breadcrumbs",
+			page: moments,
+		},
+		{
+			name: "archive page skips missing ancestors",
+			page: model.Page{Title: "March 2025", Slug: "moments/2025/03", Path: "/moments/2025/03/"},
+			wantItems: []string{
+				"https://example.com/",
+				"https://example.com/moments/",
+				"https://example.com/moments/2025/03/",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := r.RenderPage(site, tt.page)
+			if err != nil {
+				t.Fatalf("RenderPage() error = %v", err)
+			}
+			crumbs, ok := jsonLDNodes(t, got)["BreadcrumbList"]
+			if tt.wantItems == nil {
+				if ok {
+					t.Errorf("RenderPage() has BreadcrumbList %v, want none", crumbs)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("RenderPage() missing BreadcrumbList")
+			}
+			var items []string
+			for i, item := range crumbs["itemListElement"].([]any) {
+				item := item.(map[string]any)
+				if item["position"] != float64(i+1) {
+					t.Errorf("item %d position = %v", i, item["position"])
+				}
+				items = append(items, item["item"].(string))
+			}
+			if strings.Join(items, " ") != strings.Join(tt.wantItems, " ") {
+				t.Errorf("BreadcrumbList items = %q, want %q", items, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestRenderBlogList_JSONLDCollectionPage(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := r.RenderBlogList(model.Site{Title: "Site", BaseURL: "https://example.com"}, nil)
+	if err != nil {
+		t.Fatalf("RenderBlogList() error = %v", err)
+	}
+	nodes := jsonLDNodes(t, got)
+	page, ok := nodes["CollectionPage"]
+	if !ok {
+		t.Fatalf("RenderBlogList() missing CollectionPage, got %v", nodes)
+	}
+	if page["url"] != "https://example.com/blog/" {
+		t.Errorf("CollectionPage url = %v, want https://example.com/blog/", page["url"])
+	}
+	if _, ok := nodes["WebSite"]; !ok {
+		t.Error("RenderBlogList() missing WebSite")
 	}
 }
 
@@ -1761,7 +2220,7 @@ func TestRenderPage_ArchiveHistoryWrapper(t *testing.T) {
 	}
 }
 
-func TestRenderPage_ArchiveEmptyHidesSection(t *testing.T) {
+func TestRenderPage_ArchivePageLinks(t *testing.T) {
 	t.Parallel()
 
 	r, err := New()
@@ -1769,44 +2228,218 @@ func TestRenderPage_ArchiveEmptyHidesSection(t *testing.T) {
 		t.Fatalf("New() error = %v", err)
 	}
 
-	site := model.Site{
-		Title:   "Test Site",
-		BaseURL: "https://example.com",
-	}
 	page := model.Page{
 		Title:             "Moments",
 		Slug:              "moments",
-		DateAnchors:       []string{"2026-02-12"},
+		Path:              "/moments/",
+		DateAnchors:       []string{"2026-02-12", "2025-12-25"},
 		CurrentMonthDates: []string{"2026-02-12"},
-		ArchivedYears:     nil, // No archived years
+		ArchivedYears: []model.YearGroup{
+			{Year: 2025, Months: []model.MonthGroup{
+				{Year: 2025, Month: "December", Dates: []string{"2025-12-25"}, Path: "/moments/2025/12/"},
+			}},
+		},
+		ArchivedAnchors: map[string]string{"2025-12-25": "/moments/2025/12/"},
 	}
 
-	got, err := r.RenderPage(site, page)
+	got, err := r.RenderPage(model.Site{Title: "Test Site"}, page)
 	if err != nil {
 		t.Fatalf("RenderPage() error = %v", err)
 	}
-
-	// Should NOT contain "No archives yet" placeholder
-	if strings.Contains(got, "No archives yet") {
-		t.Error("RenderPage() should not display 'No archives yet' when archive is empty")
-	}
-
-	// Should NOT contain History summary when empty
-	if strings.Contains(got, "<summary>History</summary>") {
-		t.Error("RenderPage() should not render History wrapper when archive is empty")
+	for _, want := range []string{
+		`<summary><a href="/moments/2025/12/">December</a></summary>`,
+		`<a href="/moments/2025/12/#2025-12-25">2025-12-25</a>`,
+		`<a href="#2026-02-12">2026-02-12</a>`,
+		`var archived = {"2025-12-25":"/moments/2025/12/"};`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderPage() missing %q", want)
+		}
 	}
 }
 
-func TestRenderPage_WithTopics(t *testing.T) {
+func TestHeatmaps(t *testing.T) {
 	t.Parallel()
 
-	r, err := New()
-	if err != nil {
-		t.Fatalf("New() error = %v", err)
+	days := []model.CalendarDay{
+		{Date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), Words: 10, URL: "/moments/2024/12/#2024-12-31"},
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Words: 40, URL: "/moments/#2026-01-01"},
+		{Date: time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC), Words: 0, URL: "/moments/#2026-01-04"},
 	}
 
-	site := model.Site{
-		Title:   "Test Site",
+	years := heatmaps(days)
+	if len(years) != 2 || years[0].Year != 2026 || years[1].Year != 2024 {
+		t.Fatalf("heatmaps() years = %+v, want 2026 and 2024 without the empty 2025", years)
+	}
+
+	cells := make(map[string]heatmapCell)
+	for _, cell := range years[0].Cells {
+		cells[cell.Date] = cell
+	}
+	if len(years[0].Cells) != 365 {
+		t.Errorf("2026 has %d cells, want 365", len(years[0].Cells))
+	}
+	// January 1, 2026 is a Thursday in the first week; January 4 is the
+	// Sunday starting the second week.
+	tests := []struct {
+		date  string
+		x, y  int
+		level int
+		url   string
+	}{
+		{"2026-01-01", 0, heatmapTop + 4*heatmapStep, 4, "/moments/#2026-01-01"},
+		{"2026-01-04", heatmapStep, heatmapTop, 1, "/moments/#2026-01-04"},
+		{"2026-01-05", heatmapStep, heatmapTop + heatmapStep, 0, ""},
+	}
+	for _, tt := range tests {
+		cell := cells[tt.date]
+		if cell.X != tt.x || cell.Y != tt.y || cell.Level != tt.level || cell.URL != tt.url {
+			t.Errorf("cell %s = %+v, want x=%d y=%d level=%d url=%q", tt.date, cell, tt.x, tt.y, tt.level, tt.url)
+		}
+	}
+
+	for _, cell := range years[1].Cells {
+		if cell.Date == "2024-12-31" && cell.Level != 1 {
+			t.Errorf("2024-12-31 level = %d, want 1 relative to the longest day", cell.Level)
+		}
+	}
+	if len(years[1].Cells) != 366 {
+		t.Errorf("2024 has %d cells, want 366", len(years[1].Cells))
+	}
+	if len(years[0].Months) != 12 || years[0].Months[0].Name != "Jan" {
+		t.Errorf("2026 month labels = %+v", years[0].Months)
+	}
+}
+
+func TestRenderPage_CalendarHeatmap(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	page := model.Page{
+		Title:       "Moments",
+		Slug:        "moments",
+		Path:        "/moments/",
+		DateAnchors: []string{"2026-01-27"},
+		Calendar: []model.CalendarDay{
+			{Date: time.Date(2026, 1, 27, 0, 0, 0, 0, time.UTC), Words: 12, URL: "/moments/#2026-01-27"},
+		},
+	}
+
+	got, err := r.RenderPage(model.Site{Title: "Test Site"}, page)
+	if err != nil {
+		t.Fatalf("RenderPage() error = %v", err)
+	}
+	for _, want := range []string{
+		`<div class="calendar-heatmap">`,
+		`<figcaption>2026</figcaption>`,
+		`aria-label="Entries in 2026"`,
+		`<a href="/moments/#2026-01-27"><rect class="heatmap-level-4"`,
+		`<title>2026-01-27: 12 words</title>`,
+		`<text class="heatmap-month" x="0" y="10">Jan</text>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderPage() missing %q", want)
+		}
+	}
+	if strings.Contains(got, "<script>") {
+		t.Error("calendar heatmap should not need JavaScript")
+	}
+}
+
+func TestRenderOnThisDay(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	page := model.Page{Title: "Moments", Path: "/moments/", OnThisDayPath: "/moments/on-this-day/"}
+	sections := []model.DateSection{
+		{PagePath: "/moments/", Anchor: "2025-03-14", Date: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), Title: "Pi day.", Content: "<p>Pi day.</p>"},
+		{PagePath: "/moments/", Anchor: "2025-03-15", Date: time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), Content: "<p>The day after.</p>"},
+	}
+
+	got, err := r.RenderOnThisDay(model.Site{Title: "Test Site", BaseURL: "https://example.com"}, page, sections, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("RenderOnThisDay() error = %v", err)
+	}
+	for _, want := range []string{
+		"<title>On this day - Moments - Test Site</title>",
+		`<link rel="canonical" href="https://example.com/moments/on-this-day/">`,
+		`<span id="on-this-day-day">March 14</span>`,
+		`data-day="03-14" data-year="2026"`,
+		`<h2><a href="/moments/#2025-03-14">2025</a> · Pi day.</h2>`,
+		"<p>Pi day.</p>",
+		`fetch("index.json")`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderOnThisDay() missing %q", want)
+		}
+	}
+	if strings.Contains(got, "The day after.") {
+		t.Error("RenderOnThisDay() should only list sections from the same day")
+	}
+
+	empty, err := r.RenderOnThisDay(model.Site{Title: "Test Site"}, page, sections, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("RenderOnThisDay() error = %v", err)
+	}
+	if !strings.Contains(empty, `class="on-this-day-empty"`) {
+		t.Error("RenderOnThisDay() without matches should show the empty message")
+	}
+}
+
+func TestRenderPage_ArchiveEmptyHidesSection(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:   "Test Site",
+		BaseURL: "https://example.com",
+	}
+	page := model.Page{
+		Title:             "Moments",
+		Slug:              "moments",
+		DateAnchors:       []string{"2026-02-12"},
+		CurrentMonthDates: []string{"2026-02-12"},
+		ArchivedYears:     nil, // No archived years
+	}
+
+	got, err := r.RenderPage(site, page)
+	if err != nil {
+		t.Fatalf("RenderPage() error = %v", err)
+	}
+
+	// Should NOT contain "No archives yet" placeholder
+	if strings.Contains(got, "No archives yet") {
+		t.Error("RenderPage() should not display 'No archives yet' when archive is empty")
+	}
+
+	// Should NOT contain History summary when empty
+	if strings.Contains(got, "<summary>History</summary>") {
+		t.Error("RenderPage() should not render History wrapper when archive is empty")
+	}
+}
+
+func TestRenderPage_WithTopics(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:   "Test Site",
 		BaseURL: "https://example.com",
 	}
 	page := model.Page{
@@ -1843,6 +2476,159 @@ func TestRenderPage_WithTopics(t *testing.T) {
 	}
 }
 
+func TestRenderPage_TopicLinks(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	page := model.Page{
+		Title: "Moments",
+		Slug:  "moments",
+		Path:  "/moments/",
+		Topics: []model.Topic{
+			{Word: "agent", Count: 27, Path: "/moments/topics/agent/"},
+			{Word: "docker", Count: 5, Path: "/moments/topics/docker/"},
+		},
+	}
+
+	got, err := r.RenderPage(model.Site{Title: "Test Site"}, page)
+	if err != nil {
+		t.Fatalf("RenderPage() error = %v", err)
+	}
+	want := `<a href="/moments/topics/agent/">agent</a>, <a href="/moments/topics/docker/">docker</a>`
+	if !strings.Contains(got, want) {
+		t.Errorf("RenderPage() should link topics to their pages, missing %q", want)
+	}
+}
+
+func TestRenderTopic(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+	page := model.Page{Title: "Moments", Path: "/moments/"}
+	topic := model.Topic{Word: "docker", Count: 3, Path: "/moments/topics/docker/"}
+	mentions := []model.TopicMention{
+		{
+			Section: model.DateSection{PagePath: "/moments/", Anchor: "2026-01-27-1430", Date: time.Date(2026, 1, 27, 14, 30, 0, 0, time.UTC), HasTime: true, Title: "Shipped."},
+			Snippet: "Moved <b> to Docker, then docker-compose and DOCKER.",
+		},
+		{
+			Section: model.DateSection{PagePath: "/moments/", Anchor: "2025-03-14", ArchivePath: "/moments/2025/03/", Date: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)},
+			Snippet: "…docker again",
+		},
+	}
+
+	got, err := r.RenderTopic(site, page, topic, mentions)
+	if err != nil {
+		t.Fatalf("RenderTopic() error = %v", err)
+	}
+	for _, want := range []string{
+		"<title>docker - Moments - Test Site</title>",
+		`<link rel="canonical" href="https://example.com/moments/topics/docker/">`,
+		"<h1>Topic: docker</h1>",
+		`Mentioned 3 times on <a href="/moments/">Moments</a>.`,
+		`<h2><a href="/moments/#2026-01-27-1430">January 27, 2026 14:30</a> · Shipped.</h2>`,
+		"Moved &lt;b&gt; to <mark>Docker</mark>, then docker-compose and <mark>DOCKER</mark>.",
+		`<h2><a href="/moments/2025/03/#2025-03-14">March 14, 2025</a></h2>`,
+		"…<mark>docker</mark> again",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderTopic() missing %q", want)
+		}
+	}
+
+	empty, err := r.RenderTopic(site, page, topic, nil)
+	if err != nil {
+		t.Fatalf("RenderTopic() error = %v", err)
+	}
+	if !strings.Contains(empty, `class="topic-empty"`) {
+		t.Error("RenderTopic() without mentions should show the empty message")
+	}
+}
+
+func TestRenderTopicIndex(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+	topics := []model.SiteTopic{
+		{
+			Word:  "docker",
+			Slug:  "docker",
+			Count: 8,
+			Posts: []model.Post{{Page: model.Page{Title: "Moving to Docker", Path: "/blog/moving/"}, Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)}},
+			Sections: []model.DateSection{
+				{PagePath: "/moments/", PageTitle: "Moments", Anchor: "2025-03-14", ArchivePath: "/moments/2025/03/", Date: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{Word: "kubernetes", Slug: "kubernetes", Count: 2},
+	}
+
+	got, err := r.RenderTopicIndex(site, topics)
+	if err != nil {
+		t.Fatalf("RenderTopicIndex() error = %v", err)
+	}
+	for _, want := range []string{
+		"<title>Topics - Test Site</title>",
+		`<link rel="canonical" href="https://example.com/topics/">`,
+		`<a class="topic-weight-5" href="#docker" title="8 mentions">docker</a>`,
+		`<a class="topic-weight-1" href="#kubernetes" title="2 mentions">kubernetes</a>`,
+		`<section class="topic-entry" id="docker">`,
+		`<a href="/blog/moving/">Moving to Docker</a>`,
+		`<a href="/moments/2025/03/#2025-03-14">Moments</a>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderTopicIndex() missing %q", want)
+		}
+	}
+}
+
+func TestCloudWeight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		count, lowest, highest int
+		want                   int
+	}{
+		{2, 2, 2, 3},
+		{2, 2, 32, 1},
+		{32, 2, 32, 5},
+		{8, 2, 32, 3},
+		{4, 2, 32, 2},
+	}
+	for _, tt := range tests {
+		if got := cloudWeight(tt.count, tt.lowest, tt.highest); got != tt.want {
+			t.Errorf("cloudWeight(%d, %d, %d) = %d, want %d", tt.count, tt.lowest, tt.highest, got, tt.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	t.Parallel()
+	got := highlight("Tests & testing, not a testcase.", model.Topic{Word: "tests", Forms: []string{"testing", "tests"}}.Words())
+	want := "<mark>Tests</mark> &amp; <mark>testing</mark>, not a testcase."
+	if string(got) != want {
+		t.Errorf("highlight() = %q, want %q", got, want)
+	}
+
+	got = highlight("More exploratory testing.", model.Topic{Word: "exploratory testing"}.Words())
+	if want := "More <mark>exploratory testing</mark>."; string(got) != want {
+		t.Errorf("highlight() = %q, want %q", got, want)
+	}
+}
+
 func TestRenderPage_WithoutTopics(t *testing.T) {
 	t.Parallel()
 
@@ -1945,3 +2731,769 @@ func TestRenderBase_GoatCounterAnalytics(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderPage_Backlinks(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+
+	page := model.Page{
+		Title:     "Garden",
+		Slug:      "garden",
+		Content:   "<p>Notes</p>",
+		Backlinks: []model.Backlink{{Title: "About", URL: "/about/"}},
+	}
+	html, err := r.RenderPage(site, page)
+	if err != nil {
+		t.Fatalf("RenderPage() error = %v", err)
+	}
+	for _, want := range []string{`<aside class="backlinks">`, "Linked from", `<a href="/about/">About</a>`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("RenderPage() should contain %q", want)
+		}
+	}
+
+	page.Backlinks = nil
+	html, err = r.RenderPage(site, page)
+	if err != nil {
+		t.Fatalf("RenderPage() error = %v", err)
+	}
+	if strings.Contains(html, "Linked from") {
+		t.Error("RenderPage() should not render backlinks when there are none")
+	}
+}
+
+func TestRenderBlogPost_Backlinks(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+	post := model.Post{
+		Page: model.Page{
+			Title:     "Hello",
+			Slug:      "hello",
+			Content:   "<p>Hi</p>",
+			Backlinks: []model.Backlink{{Title: "Garden", URL: "/garden/"}},
+		},
+		Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	html, err := r.RenderBlogPost(site, post)
+	if err != nil {
+		t.Fatalf("RenderBlogPost() error = %v", err)
+	}
+	if !strings.Contains(html, `<a href="/garden/">Garden</a>`) {
+		t.Errorf("RenderBlogPost() should contain backlink, got %s", html)
+	}
+}
+
+func TestRenderAtomFeed(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:       "Test Site",
+		Description: "A test site",
+		BaseURL:     "https://example.com",
+		Author:      "Jane Doe",
+	}
+	posts := []model.Post{
+		{
+			Page: model.Page{Title: "Older", Slug: "older", Content: "<p>Old</p>"},
+			Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Page: model.Page{Title: "Newer & Better", Slug: "newer", Content: "<p>New</p>"},
+			Date: time.Date(2024, 2, 20, 8, 30, 0, 0, time.UTC),
+		},
+	}
+
+	got, err := r.RenderAtomFeed(site, postsToFeedItems(posts, site.BaseURL))
+	if err != nil {
+		t.Fatalf("RenderAtomFeed() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		"<title>Test Site</title>",
+		"<subtitle>A test site</subtitle>",
+		`<link rel="self" type="application/atom+xml" href="https://example.com/atom.xml"></link>`,
+		"<id>https://example.com/</id>",
+		"<updated>2024-02-20T08:30:00Z</updated>",
+		"<name>Jane Doe</name>",
+		"<title>Newer &amp; Better</title>",
+		`<link rel="alternate" type="text/html" href="https://example.com/blog/newer/"></link>`,
+		"<id>https://example.com/blog/newer/</id>",
+		"<published>2024-01-10T00:00:00Z</published>",
+		`<content type="html">&lt;p&gt;New&lt;/p&gt;</content>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderAtomFeed() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderAtomFeed_AuthorFallsBackToSiteTitle(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+	posts := []model.Post{{Page: model.Page{Title: "Post", Slug: "post"}, Date: time.Now()}}
+
+	got, err := r.RenderAtomFeed(site, postsToFeedItems(posts, site.BaseURL))
+	if err != nil {
+		t.Fatalf("RenderAtomFeed() error = %v", err)
+	}
+	if !strings.Contains(got, "<author>\n    <name>Test Site</name>\n  </author>") {
+		t.Errorf("RenderAtomFeed() should use site title as author, got:\n%s", got)
+	}
+}
+
+func TestRenderJSONFeed(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:       "Test Site",
+		Description: "A test site",
+		BaseURL:     "https://example.com",
+		Author:      "Jane Doe",
+	}
+	items := []model.FeedItem{
+		model.DateSection{
+			PageTitle: "Moments",
+			PagePath:  "/moments/",
+			Date:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			Anchor:    "2024-03-01",
+			Content:   "<p>A <em>moment</em></p>",
+			BaseURL:   site.BaseURL,
+		},
+	}
+
+	got, err := r.RenderJSONFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderJSONFeed() error = %v", err)
+	}
+
+	var feed struct {
+		Version     string `json:"version"`
+		Title       string `json:"title"`
+		HomePageURL string `json:"home_page_url"`
+		FeedURL     string `json:"feed_url"`
+		Description string `json:"description"`
+		Authors     []struct {
+			Name string `json:"name"`
+		} `json:"authors"`
+		Items []struct {
+			ID            string `json:"id"`
+			URL           string `json:"url"`
+			Title         string `json:"title"`
+			ContentHTML   string `json:"content_html"`
+			DatePublished string `json:"date_published"`
+			DateModified  string `json:"date_modified"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(got), &feed); err != nil {
+		t.Fatalf("RenderJSONFeed() produced invalid JSON: %v\n%s", err, got)
+	}
+
+	if feed.Version != "https://jsonfeed.org/version/1.1" {
+		t.Errorf("version = %q", feed.Version)
+	}
+	if feed.Title != "Test Site" || feed.HomePageURL != "https://example.com/" || feed.FeedURL != "https://example.com/feed.json" {
+		t.Errorf("feed metadata = %+v", feed)
+	}
+	if len(feed.Authors) != 1 || feed.Authors[0].Name != "Jane Doe" {
+		t.Errorf("authors = %+v, want Jane Doe", feed.Authors)
+	}
+	if len(feed.Items) != 1 {
+		t.Fatalf("items = %d, want 1", len(feed.Items))
+	}
+	item := feed.Items[0]
+	if item.ID != "https://example.com/moments/#2024-03-01" || item.URL != item.ID {
+		t.Errorf("item id/url = %q/%q", item.ID, item.URL)
+	}
+	if item.Title != "Moments - March 1, 2024" {
+		t.Errorf("item title = %q", item.Title)
+	}
+	if item.ContentHTML != "<p>A <em>moment</em></p>" {
+		t.Errorf("item content_html = %q", item.ContentHTML)
+	}
+	if item.DatePublished != "2024-03-01T00:00:00Z" || item.DateModified != item.DatePublished {
+		t.Errorf("item dates = %q/%q", item.DatePublished, item.DateModified)
+	}
+	if strings.Contains(got, `\u003c`) {
+		t.Error("RenderJSONFeed() should not escape HTML in content_html")
+	}
+}
+
+func TestRenderAtomAndJSONFeed_EmptyItems(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+
+	atom, err := r.RenderAtomFeed(site, nil)
+	if err != nil || atom != "" {
+		t.Errorf("RenderAtomFeed() = %q, %v, want empty string", atom, err)
+	}
+	jsonFeed, err := r.RenderJSONFeed(site, nil)
+	if err != nil || jsonFeed != "" {
+		t.Errorf("RenderJSONFeed() = %q, %v, want empty string", jsonFeed, err)
+	}
+}
+
+func TestRenderBase_FeedAlternateLinks(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:       "Test Site",
+		BaseURL:     "https://example.com",
+		FeedFormats: []string{"atom", "json"},
+	}
+
+	got, err := r.RenderBase(site, "Test Content")
+	if err != nil {
+		t.Fatalf("RenderBase() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`<link rel="alternate" type="application/atom&#43;xml" title="Atom Feed" href="/atom.xml">`,
+		`<link rel="alternate" type="application/feed&#43;json" title="JSON Feed" href="/feed.json">`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderBase() missing %q", want)
+		}
+	}
+	if strings.Contains(got, `href="/feed.xml"`) {
+		t.Error("RenderBase() should not advertise RSS when it is not configured")
+	}
+}
+
+func TestAbsoluteURLs(t *testing.T) {
+	t.Parallel()
+
+	const base = "https://example.com/blog/post/"
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "relative image",
+			content: `<p><img src="photo.jpg" alt="A photo"></p>`,
+			want:    `<p><img src="https://example.com/blog/post/photo.jpg" alt="A photo"></p>`,
+		},
+		{
+			name:    "root-relative link",
+			content: `<a href="/about/">About</a>`,
+			want:    `<a href="https://example.com/about/">About</a>`,
+		},
+		{
+			name:    "fragment link",
+			content: `<a href="#2024-01-15">Jan 15</a>`,
+			want:    `<a href="https://example.com/blog/post/#2024-01-15">Jan 15</a>`,
+		},
+		{
+			name:    "parent-relative link",
+			content: `<a href="../other/">Other</a>`,
+			want:    `<a href="https://example.com/blog/other/">Other</a>`,
+		},
+		{
+			name:    "absolute and mailto URLs unchanged",
+			content: `<a href="https://other.org/x">x</a> <a href="mailto:me@example.com">me</a>`,
+			want:    `<a href="https://other.org/x">x</a> <a href="mailto:me@example.com">me</a>`,
+		},
+		{
+			name:    "text and code are untouched",
+			content: `<p>Use <code>&lt;img src="x.png"&gt;</code> &amp; "quotes"</p>`,
+			want:    `<p>Use <code>&lt;img src="x.png"&gt;</code> &amp; "quotes"</p>`,
+		},
+		{
+			name:    "other attributes untouched",
+			content: `<a class="wikilink" title="/x" href="/garden/">Garden</a>`,
+			want:    `<a class="wikilink" title="/x" href="https://example.com/garden/">Garden</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := absoluteURLs(tt.content, base); got != tt.want {
+				t.Errorf("absoluteURLs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderFeeds_ResolveRelativeURLs(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+	posts := []model.Post{
+		{
+			Page: model.Page{
+				Title:   "Photos",
+				Slug:    "photos",
+				Content: `<p><img src="sunset.jpg" alt="Sunset"> See <a href="/about/">about</a>.</p>`,
+			},
+			Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	items := postsToFeedItems(posts, site.BaseURL)
+
+	rss, err := r.RenderFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderFeed() error = %v", err)
+	}
+	atom, err := r.RenderAtomFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderAtomFeed() error = %v", err)
+	}
+	jsonFeed, err := r.RenderJSONFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderJSONFeed() error = %v", err)
+	}
+
+	for name, feed := range map[string]string{"rss": rss, "atom": atom, "json": jsonFeed} {
+		for _, want := range []string{"https://example.com/blog/photos/sunset.jpg", "https://example.com/about/"} {
+			if !strings.Contains(feed, want) {
+				t.Errorf("%s feed missing %q:\n%s", name, want, feed)
+			}
+		}
+	}
+}
+
+func TestRenderFeed_RichItemElements(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com", Author: "Site Author"}
+	posts := []model.Post{
+		{
+			Page:   model.Page{Title: "Tagged", Slug: "tagged", Content: "<p>Tagged</p>"},
+			Date:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Author: "Guest Writer",
+			Tags:   []string{"go", "feeds"},
+			GUID:   "urn:uuid:1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+		},
+		{
+			Page: model.Page{Title: "Plain", Slug: "plain", Content: "<p>Plain</p>"},
+			Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	got, err := r.RenderFeed(site, postsToFeedItems(posts, site.BaseURL))
+	if err != nil {
+		t.Fatalf("RenderFeed() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`<atom:link rel="self" type="application/rss+xml" href="https://example.com/feed.xml"></atom:link>`,
+		"<dc:creator>Guest Writer</dc:creator>",
+		"<dc:creator>Site Author</dc:creator>",
+		"<category>go</category>",
+		"<category>feeds</category>",
+		`<guid isPermaLink="false">urn:uuid:1b4e28ba-2fa1-11d2-883f-0016d3cca427</guid>`,
+		`<guid isPermaLink="true">https://example.com/blog/plain/</guid>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderFeed() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderFeeds_MediaEnclosures(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com", Author: "Site Author"}
+	posts := []model.Post{
+		{
+			Page:     model.Page{Title: "Episode 1", Slug: "episode-1", Content: "<p>Notes</p>"},
+			Date:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Audio:    &model.Media{Path: "assets/episode-1.mp3", Type: "audio/mpeg", Length: 1024},
+			Duration: "1:02:03",
+			Episode:  1,
+		},
+	}
+	items := postsToFeedItems(posts, site.BaseURL)
+
+	rss, err := r.RenderFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderFeed() error = %v", err)
+	}
+	if want := `<enclosure url="https://example.com/blog/episode-1/episode-1.mp3" length="1024" type="audio/mpeg"></enclosure>`; !strings.Contains(rss, want) {
+		t.Errorf("RenderFeed() missing %q in:\n%s", want, rss)
+	}
+	if strings.Contains(rss, "itunes") {
+		t.Errorf("RenderFeed() without podcast config should not contain iTunes tags:\n%s", rss)
+	}
+
+	atom, err := r.RenderAtomFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderAtomFeed() error = %v", err)
+	}
+	if want := `<link rel="enclosure" type="audio/mpeg" length="1024" href="https://example.com/blog/episode-1/episode-1.mp3"></link>`; !strings.Contains(atom, want) {
+		t.Errorf("RenderAtomFeed() missing %q in:\n%s", want, atom)
+	}
+
+	jsonFeed, err := r.RenderJSONFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderJSONFeed() error = %v", err)
+	}
+	var feed struct {
+		Items []struct {
+			Attachments []jsonFeedAttachment `json:"attachments"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(jsonFeed), &feed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := jsonFeedAttachment{URL: "https://example.com/blog/episode-1/episode-1.mp3", MimeType: "audio/mpeg", SizeInBytes: 1024, DurationInSeconds: 3723}
+	if len(feed.Items) != 1 || len(feed.Items[0].Attachments) != 1 || feed.Items[0].Attachments[0] != want {
+		t.Errorf("attachments = %+v, want [%+v]", feed.Items, want)
+	}
+}
+
+func TestRenderFeed_PodcastTags(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:   "Test Podcast",
+		BaseURL: "https://example.com",
+		Author:  "Jane Doe",
+		Podcast: &model.Podcast{
+			Email:       "jane@example.com",
+			Image:       "/podcast.jpg",
+			Category:    "Technology",
+			Subcategory: "Tech News",
+			Type:        "episodic",
+		},
+	}
+	posts := []model.Post{
+		{
+			Page:     model.Page{Title: "Episode 1", Slug: "episode-1", Content: "<p>Notes</p>"},
+			Date:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Audio:    &model.Media{Path: "assets/episode-1.mp3", Type: "audio/mpeg", Length: 1024},
+			Duration: "32:15",
+			Episode:  1,
+		},
+	}
+
+	got, err := r.RenderFeed(site, postsToFeedItems(posts, site.BaseURL))
+	if err != nil {
+		t.Fatalf("RenderFeed() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"`,
+		"<itunes:author>Jane Doe</itunes:author>",
+		"<itunes:name>Jane Doe</itunes:name>",
+		"<itunes:email>jane@example.com</itunes:email>",
+		`<itunes:image href="https://example.com/podcast.jpg"></itunes:image>`,
+		`<itunes:category text="Technology">`,
+		`<itunes:category text="Tech News"></itunes:category>`,
+		"<itunes:explicit>false</itunes:explicit>",
+		"<itunes:type>episodic</itunes:type>",
+		"<itunes:duration>32:15</itunes:duration>",
+		"<itunes:episode>1</itunes:episode>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderFeed() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderBlogPost_MediaPlayers(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	post := model.Post{
+		Page:  model.Page{Title: "Episode 1", Slug: "episode-1", Content: "<p>Notes</p>"},
+		Date:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Audio: &model.Media{Path: "assets/episode-1.mp3", Type: "audio/mpeg"},
+		Video: &model.Media{Path: "assets/episode-1.webm", Type: "video/webm"},
+	}
+
+	got, err := r.RenderBlogPost(model.Site{Title: "Test Site"}, post)
+	if err != nil {
+		t.Fatalf("RenderBlogPost() error = %v", err)
+	}
+	for _, want := range []string{
+		`<audio class="media-player" controls preload="metadata"><source src="assets/episode-1.mp3" type="audio/mpeg"></audio>`,
+		`<video class="media-player" controls preload="metadata"><source src="assets/episode-1.webm" type="video/webm"></video>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderBlogPost() missing %q", want)
+		}
+	}
+}
+
+func TestRenderSectionFeed(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com"}
+	posts := []model.Post{
+		{
+			Page: model.Page{Title: "Tagged", Slug: "tagged", Content: "<p>Tagged</p>"},
+			Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	link := model.FeedLink{Format: model.FeedRSS, Type: "application/rss+xml", Title: "Tag: go", Href: "/tags/go/index.xml"}
+
+	got, err := r.RenderSectionFeed(site, link, postsToFeedItems(posts, site.BaseURL))
+	if err != nil {
+		t.Fatalf("RenderSectionFeed() error = %v", err)
+	}
+	for _, want := range []string{
+		"<title>Test Site - Tag: go</title>",
+		"<link>https://example.com/tags/go/</link>",
+		`<atom:link rel="self" type="application/rss+xml" href="https://example.com/tags/go/index.xml"></atom:link>`,
+		"<link>https://example.com/blog/tagged/</link>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderSectionFeed() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestRender_SectionFeedAlternateLinks(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{
+		Title:     "Test Site",
+		BaseURL:   "https://example.com",
+		Pages:     []model.Page{{Title: "Now", Slug: "now", Path: "/now/"}, {Title: "About", Slug: "about", Path: "/about/"}},
+		Posts:     []model.Post{{Page: model.Page{Title: "Post", Slug: "post"}, Tags: []string{"go"}}},
+		FeedPages: []string{"/now/"},
+	}
+
+	blogList, err := r.RenderBlogList(site, site.Posts)
+	if err != nil {
+		t.Fatalf("RenderBlogList() error = %v", err)
+	}
+	post, err := r.RenderBlogPost(site, site.Posts[0])
+	if err != nil {
+		t.Fatalf("RenderBlogPost() error = %v", err)
+	}
+	nowPage, err := r.RenderPage(site, site.Pages[0])
+	if err != nil {
+		t.Fatalf("RenderPage() error = %v", err)
+	}
+	aboutPage, err := r.RenderPage(site, site.Pages[1])
+	if err != nil {
+		t.Fatalf("RenderPage() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{name: "blog", html: blogList, want: `<link rel="alternate" type="application/rss&#43;xml" title="Test Site - Blog" href="/blog/index.xml">`},
+		{name: "tagged post", html: post, want: `<link rel="alternate" type="application/rss&#43;xml" title="Test Site - Tag: go" href="/tags/go/index.xml">`},
+		{name: "feed page", html: nowPage, want: `<link rel="alternate" type="application/rss&#43;xml" title="Test Site - Now" href="/now/index.xml">`},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(tt.html, tt.want) {
+			t.Errorf("%s page missing %q", tt.name, tt.want)
+		}
+	}
+	if strings.Contains(aboutPage, "index.xml") {
+		t.Error("page without a section feed should not link to index.xml")
+	}
+}
+
+func TestRenderFeeds_ConfigurableLimit(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com", FeedLimit: 3}
+	var posts []model.Post
+	for i := 0; i < 5; i++ {
+		posts = append(posts, model.Post{
+			Page: model.Page{Title: "Post", Slug: "post-" + string(rune('a'+i))},
+			Date: time.Date(2024, 1, 5-i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+	items := postsToFeedItems(posts, site.BaseURL)
+
+	rss, err := r.RenderFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderFeed() error = %v", err)
+	}
+	if n := strings.Count(rss, "<item>"); n != 3 {
+		t.Errorf("RenderFeed() items = %d, want 3", n)
+	}
+	atom, err := r.RenderAtomFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderAtomFeed() error = %v", err)
+	}
+	if n := strings.Count(atom, "<entry>"); n != 3 {
+		t.Errorf("RenderAtomFeed() entries = %d, want 3", n)
+	}
+	jsonFeed, err := r.RenderJSONFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderJSONFeed() error = %v", err)
+	}
+	if n := strings.Count(jsonFeed, `"date_published"`); n != 3 {
+		t.Errorf("RenderJSONFeed() items = %d, want 3", n)
+	}
+}
+
+func TestRenderFeeds_SummaryOnly(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com", FeedSummaryOnly: true}
+	posts := []model.Post{
+		{
+			Page:    model.Page{Title: "Summarized", Slug: "summarized", Content: "<p>Full body text</p>"},
+			Date:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Summary: "Short & sweet",
+		},
+		{
+			Page: model.Page{Title: "Unsummarized", Slug: "unsummarized", Content: "<p>Only body</p>"},
+			Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	items := postsToFeedItems(posts, site.BaseURL)
+
+	rss, err := r.RenderFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderFeed() error = %v", err)
+	}
+	if !strings.Contains(rss, "<![CDATA[Short &amp; sweet]]>") || strings.Contains(rss, "Full body text") {
+		t.Errorf("RenderFeed() should publish the summary only:\n%s", rss)
+	}
+	if !strings.Contains(rss, "Only body") {
+		t.Error("RenderFeed() should publish full content for items without a summary")
+	}
+
+	atom, err := r.RenderAtomFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderAtomFeed() error = %v", err)
+	}
+	if !strings.Contains(atom, `<summary type="text">Short &amp; sweet</summary>`) || strings.Contains(atom, "Full body text") {
+		t.Errorf("RenderAtomFeed() should publish the summary only:\n%s", atom)
+	}
+
+	jsonFeed, err := r.RenderJSONFeed(site, items)
+	if err != nil {
+		t.Fatalf("RenderJSONFeed() error = %v", err)
+	}
+	if !strings.Contains(jsonFeed, `"content_text": "Short & sweet"`) || strings.Contains(jsonFeed, "Full body text") {
+		t.Errorf("RenderJSONFeed() should publish the summary only:\n%s", jsonFeed)
+	}
+}
+
+func TestRenderFeeds_Excerpts(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	site := model.Site{Title: "Test Site", BaseURL: "https://example.com", FeedExcerpts: true}
+	posts := []model.Post{
+		{
+			Page:    model.Page{Title: "Excerpted", Slug: "excerpted", Content: "<p>Intro <img src=\"assets/a.png\"></p>\n<!--more-->\n<p>Rest of the body</p>"},
+			Date:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Excerpt: "<p>Intro <img src=\"assets/a.png\"></p>",
+		},
+		{
+			Page: model.Page{Title: "Whole", Slug: "whole", Content: "<p>Only body</p>"},
+			Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	items := postsToFeedItems(posts, site.BaseURL)
+
+	for name, render := range map[string]func(model.Site, []model.FeedItem) (string, error){
+		"RenderFeed":     r.RenderFeed,
+		"RenderAtomFeed": r.RenderAtomFeed,
+		"RenderJSONFeed": r.RenderJSONFeed,
+	} {
+		got, err := render(site, items)
+		if err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		if strings.Contains(got, "Rest of the body") {
+			t.Errorf("%s() should publish the excerpt only", name)
+		}
+		for _, want := range []string{"https://example.com/blog/excerpted/assets/a.png", "Read more…", "Only body"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s() missing %q", name, want)
+			}
+		}
+	}
+}
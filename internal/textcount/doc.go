@@ -0,0 +1,8 @@
+// Package textcount counts the words of plain text by script and estimates
+// its reading time.
+//
+// [Count] counts Chinese and Japanese characters individually, as those
+// languages are written without spaces, and [ReadingTime] reads them 2.5
+// times as fast as words. Markdown is counted with the wordcount package,
+// which extracts its text with the parser first.
+package textcount
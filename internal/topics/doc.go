@@ -1,2 +1,12 @@
-// Package topics extracts recurring subject words from markdown content.
+// Package topics extracts recurring subject words from the plain text of
+// pages and posts.
+//
+// [Extract] ranks words by frequency with default settings, and
+// [ExtractWithSettings] applies a page's [model.TopicSettings]: its limits,
+// the stop words of its language, extra stop words read with
+// [ReadStopWords], and light suffix stemming. [Languages] lists the
+// built-in languages. [ExtractWithCorpus] adds the TF-IDF mode, which finds
+// two- and three-word phrases and weights terms against the site's other
+// pages and posts. [Snippet] and [Mentions] find a topic word or phrase in
+// the plain text of a date section for topic pages.
 package topics
@@ -0,0 +1,141 @@
+package topics
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultLanguage is the language used when none is set or supported.
+const DefaultLanguage = "en"
+
+// language holds the stop words and stemming rules of a language.
+type language struct {
+	stopWords map[string]bool
+	suffixes  []suffixRule
+	undouble  bool // Reduce a doubled final consonant left by stemming, as in "running"
+}
+
+// suffixRule replaces a word ending with a shorter one.
+type suffixRule struct {
+	suffix      string
+	replacement string
+}
+
+// minStemLength is the minimum length in runes of a stemmed word.
+const minStemLength = 3
+
+// languages lists the supported languages by ISO 639-1 code.
+var languages = map[string]language{
+	"en": {
+		stopWords: englishStopWords,
+		suffixes: []suffixRule{
+			{"sses", "ss"}, {"ies", "y"}, {"ss", "ss"}, {"us", "us"}, {"is", "is"},
+			{"s", ""}, {"ing", ""}, {"ed", ""},
+		},
+		undouble: true,
+	},
+	"nl": {
+		stopWords: dutchStopWords,
+		suffixes:  []suffixRule{{"heden", "heid"}, {"en", ""}, {"s", ""}, {"e", ""}},
+		undouble:  true,
+	},
+	"de": {
+		stopWords: germanStopWords,
+		suffixes: []suffixRule{
+			{"ern", ""}, {"em", ""}, {"en", ""}, {"er", ""}, {"es", ""},
+			{"e", ""}, {"n", ""}, {"s", ""},
+		},
+	},
+	"fr": {
+		stopWords: frenchStopWords,
+		suffixes:  []suffixRule{{"aux", "al"}, {"es", ""}, {"s", ""}, {"x", ""}, {"e", ""}},
+	},
+	"es": {
+		stopWords: spanishStopWords,
+		suffixes:  []suffixRule{{"ces", "z"}, {"es", ""}, {"s", ""}},
+	},
+}
+
+// Language returns the supported language of a language tag such as "nl" or
+// "nl-BE", and reports whether it is supported.
+func Language(tag string) (string, bool) {
+	code, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	code, _, _ = strings.Cut(code, "_")
+	if _, ok := languages[code]; !ok {
+		return "", false
+	}
+	return code, true
+}
+
+// Languages returns the codes of the supported languages in sorted order.
+func Languages() []string {
+	codes := make([]string, 0, len(languages))
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// lookupLanguage returns the language of tag, or the default language when
+// tag is empty or unsupported.
+func lookupLanguage(tag string) language {
+	if code, ok := Language(tag); ok {
+		return languages[code]
+	}
+	return languages[DefaultLanguage]
+}
+
+// stem strips a common inflection suffix from a lowercase word, so that
+// inflections like "test", "tests" and "testing" share a stem. Only the first
+// matching rule applies, and words are never cut below minStemLength runes.
+func (l language) stem(word string) string {
+	for _, rule := range l.suffixes {
+		if !strings.HasSuffix(word, rule.suffix) {
+			continue
+		}
+		stem := strings.TrimSuffix(word, rule.suffix)
+		if utf8.RuneCountInString(stem)+utf8.RuneCountInString(rule.replacement) < minStemLength {
+			return word
+		}
+		if rule.replacement == "" && l.undouble {
+			stem = undouble(stem)
+		}
+		return stem + rule.replacement
+	}
+	return word
+}
+
+// undouble reduces a doubled final consonant, as in "runn" from "running".
+// Doubled l, s and z are kept, as they are common in stems like "install".
+func undouble(stem string) string {
+	last, size := utf8.DecodeLastRuneInString(stem)
+	prev, _ := utf8.DecodeLastRuneInString(stem[:len(stem)-size])
+	if last != prev || strings.ContainsRune("aeiouylsz", last) {
+		return stem
+	}
+	if utf8.RuneCountInString(stem)-1 < minStemLength {
+		return stem
+	}
+	return stem[:len(stem)-size]
+}
+
+// ReadStopWords reads extra stop words from r, separated by whitespace.
+// Text after a # on a line is a comment. Words are lowercased.
+func ReadStopWords(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, w := range strings.Fields(line) {
+			words = append(words, strings.ToLower(w))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
@@ -0,0 +1,82 @@
+package topics
+
+import (
+	"strings"
+)
+
+// snippetRadius is the number of words kept on each side of a mention in
+// a snippet.
+const snippetRadius = 12
+
+// Mentions returns the byte ranges of the case-insensitive, whole-word
+// mentions of any of words in text, using the same word boundaries as
+// [Extract]. Words may be phrases, whose words must be separated by
+// whitespace only in text.
+func Mentions(text string, words []string) [][2]int {
+	var mentions [][2]int
+	spans := wordSpans(text)
+	for i := 0; i < len(spans); i++ {
+		if n := matchAt(text, spans, i, words); n > 0 {
+			mentions = append(mentions, [2]int{spans[i][0], spans[i+n-1][1]})
+			i += n - 1
+		}
+	}
+	return mentions
+}
+
+// Snippet returns the words of text around the first mention of any of
+// words, with an ellipsis where text was cut and whitespace collapsed. It
+// reports false when text mentions none of words.
+func Snippet(text string, words []string) (string, bool) {
+	text = strings.Join(strings.Fields(text), " ")
+	spans := wordSpans(text)
+	for i := range spans {
+		n := matchAt(text, spans, i, words)
+		if n == 0 {
+			continue
+		}
+		first := max(0, i-snippetRadius)
+		last := min(len(spans)-1, i+n-1+snippetRadius)
+
+		start, end := spans[first][0], spans[last][1]
+		if first == 0 {
+			start = 0
+		}
+		if last == len(spans)-1 {
+			end = len(text)
+		}
+		snippet := text[start:end]
+		if start > 0 {
+			snippet = "…" + snippet
+		}
+		if end < len(text) {
+			snippet += "…"
+		}
+		return snippet, true
+	}
+	return "", false
+}
+
+// matchAt returns the number of words of the first of words that matches
+// text at the word spans[i], ignoring case, or 0 when none matches.
+func matchAt(text string, spans [][2]int, i int, words []string) int {
+	for _, w := range words {
+		fields := strings.Fields(w)
+		if len(fields) == 0 || i+len(fields) > len(spans) {
+			continue
+		}
+		matched := true
+		for j, field := range fields {
+			span := spans[i+j]
+			if !strings.EqualFold(text[span[0]:span[1]], field) ||
+				j > 0 && strings.TrimSpace(text[spans[i+j-1][1]:span[0]]) != "" {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return len(fields)
+		}
+	}
+	return 0
+}
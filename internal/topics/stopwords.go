@@ -0,0 +1,281 @@
+package topics
+
+// englishStopWords contains common English words to exclude from topic extraction.
+var englishStopWords = map[string]bool{
+	// Articles
+	"the": true, "a": true, "an": true,
+	// Prepositions
+	"in": true, "on": true, "at": true, "to": true, "for": true,
+	"with": true, "from": true, "by": true, "of": true, "about": true,
+	"into": true, "through": true, "during": true, "before": true,
+	"after": true, "above": true, "below": true, "between": true,
+	"under": true, "over": true, "out": true, "off": true, "up": true,
+	"down": true, "upon": true, "along": true, "across": true, "via": true,
+	// Pronouns
+	"he": true, "she": true, "it": true, "they": true, "we": true,
+	"you": true, "his": true, "her": true, "its": true, "their": true,
+	"our": true, "your": true, "him": true, "them": true, "who": true,
+	"whom": true, "whose": true, "which": true, "that": true,
+	"this": true, "these": true, "those": true, "what": true,
+	"myself": true, "yourself": true, "himself": true, "herself": true,
+	"itself": true, "ourselves": true, "themselves": true,
+	// Common verbs
+	"is": true, "are": true, "was": true, "were": true, "be": true,
+	"been": true, "being": true, "has": true, "have": true, "had": true,
+	"having": true, "do": true, "does": true, "did": true, "doing": true,
+	"will": true, "would": true, "shall": true, "should": true,
+	"may": true, "might": true, "must": true, "can": true, "could": true,
+	"am": true, "get": true, "got": true, "gets": true, "make": true,
+	"made": true, "let": true, "say": true, "said": true, "know": true,
+	"think": true, "take": true, "come": true, "see": true, "want": true,
+	"use": true, "used": true, "using": true, "find": true, "give": true,
+	"tell": true, "work": true, "call": true, "try": true, "ask": true,
+	"need": true, "seem": true, "feel": true, "leave": true, "put": true,
+	"keep": true, "set": true, "run": true, "move": true, "go": true,
+	"went": true, "gone": true, "going": true,
+	// Conjunctions
+	"and": true, "but": true, "or": true, "nor": true, "so": true,
+	"yet": true, "both": true, "either": true, "neither": true,
+	"not": true, "only": true, "own": true, "same": true,
+	// Common adverbs
+	"also": true, "just": true, "then": true, "than": true,
+	"now": true, "here": true, "there": true, "when": true,
+	"where": true, "why": true, "how": true, "all": true,
+	"each": true, "every": true, "any": true, "few": true,
+	"more": true, "most": true, "other": true, "some": true,
+	"such": true, "no": true, "very": true, "too": true,
+	"quite": true, "enough": true, "well": true, "back": true,
+	"still": true, "even": true, "never": true, "always": true,
+	"often": true, "ever": true, "much": true, "many": true,
+	// Other common words
+	"like": true, "one": true, "two": true,
+	"new": true, "old": true, "first": true, "last": true,
+	"long": true, "great": true, "little": true, "right": true,
+	"big": true, "high": true, "small": true, "large": true,
+	"next": true, "early": true, "young": true, "important": true,
+	"public": true, "bad": true, "different": true, "able": true,
+	"way": true, "day": true, "time": true, "year": true,
+	"people": true, "part": true, "place": true, "case": true,
+	"thing": true, "man": true, "world": true, "life": true,
+	"hand": true, "point": true, "end": true, "another": true,
+	"again": true, "don": true, "article": true, "post": true, "dev": true,
+	"based": true,
+}
+
+// dutchStopWords contains common Dutch words to exclude from topic extraction.
+var dutchStopWords = map[string]bool{
+	// Articles
+	"de": true, "het": true, "een": true,
+	// Prepositions
+	"in": true, "op": true, "aan": true, "te": true, "voor": true,
+	"met": true, "van": true, "uit": true, "door": true, "bij": true,
+	"naar": true, "over": true, "onder": true, "tussen": true,
+	"tegen": true, "zonder": true, "na": true, "om": true, "tot": true,
+	"sinds": true, "tijdens": true, "binnen": true, "buiten": true,
+	"achter": true, "boven": true, "langs": true, "via": true,
+	"per": true,
+	// Pronouns
+	"ik": true, "je": true, "jij": true, "u": true, "hij": true,
+	"zij": true, "ze": true, "wij": true, "we": true, "jullie": true,
+	"mij": true, "me": true, "hem": true, "haar": true, "ons": true,
+	"hun": true, "hen": true, "mijn": true, "jouw": true, "uw": true,
+	"zijn": true, "zich": true, "dit": true, "dat": true, "deze": true,
+	"die": true, "wat": true, "wie": true, "welke": true, "waar": true,
+	"iets": true, "niets": true, "iemand": true, "niemand": true,
+	"elk": true, "elke": true,
+	// Common verbs
+	"is": true, "ben": true, "bent": true, "was": true,
+	"waren": true, "wordt": true, "worden": true, "werd": true,
+	"werden": true, "geweest": true, "heb": true, "hebt": true,
+	"heeft": true, "hebben": true, "had": true, "hadden": true,
+	"kan": true, "kun": true, "kunt": true, "kunnen": true, "kon": true,
+	"konden": true, "zal": true, "zult": true, "zullen": true,
+	"zou": true, "zouden": true, "moet": true, "moeten": true,
+	"moest": true, "wil": true, "wilt": true, "willen": true,
+	"wilde": true, "mag": true, "mogen": true, "doe": true, "doet": true,
+	"doen": true, "deed": true, "gaat": true, "gaan": true, "ging": true,
+	"kom": true, "komt": true, "komen": true, "kwam": true, "zie": true,
+	"ziet": true, "zien": true, "zag": true, "laat": true, "laten": true,
+	"maak": true, "maakt": true, "maken": true,
+	// Conjunctions
+	"en": true, "of": true, "maar": true, "want": true, "dus": true,
+	"omdat": true, "als": true, "dan": true, "toen": true,
+	"terwijl": true, "hoewel": true, "noch": true,
+	"zowel": true,
+	// Common adverbs
+	"niet": true, "geen": true, "wel": true, "ook": true, "nog": true,
+	"al": true, "er": true, "hier": true, "daar": true, "nu": true,
+	"zo": true, "heel": true, "erg": true, "veel": true,
+	"meer": true, "meest": true, "weinig": true, "minder": true,
+	"altijd": true, "nooit": true, "vaak": true, "soms": true,
+	"even": true, "echt": true, "gewoon": true, "weer": true,
+	"alleen": true, "eigenlijk": true, "misschien": true,
+	"natuurlijk": true, "steeds": true, "toch": true,
+	// Other common words
+	"alle": true, "allemaal": true, "andere": true, "ander": true,
+	"dag": true, "jaar": true, "tijd": true, "keer": true, "manier": true,
+	"goed": true, "nieuw": true, "nieuwe": true, "oud": true,
+	"oude": true, "eerste": true, "laatste": true, "grote": true,
+	"groot": true, "kleine": true, "klein": true,
+}
+
+// germanStopWords contains common German words to exclude from topic extraction.
+var germanStopWords = map[string]bool{
+	// Articles
+	"der": true, "die": true, "das": true, "den": true, "dem": true,
+	"des": true, "ein": true, "eine": true, "einen": true, "einem": true,
+	"einer": true, "eines": true,
+	// Prepositions
+	"in": true, "im": true, "an": true, "am": true, "auf": true,
+	"aus": true, "bei": true, "mit": true, "nach": true, "von": true,
+	"vom": true, "zu": true, "zum": true, "zur": true, "für": true,
+	"über": true, "unter": true, "vor": true, "hinter": true,
+	"neben": true, "zwischen": true, "durch": true, "gegen": true,
+	"ohne": true, "um": true, "bis": true, "seit": true, "während": true,
+	"wegen": true,
+	// Pronouns
+	"ich": true, "du": true, "er": true, "sie": true, "es": true,
+	"wir": true, "ihr": true, "mich": true, "dich": true, "ihn": true,
+	"uns": true, "euch": true, "mir": true, "dir": true, "ihm": true,
+	"ihnen": true, "mein": true, "meine": true, "dein": true,
+	"deine": true, "sein": true, "seine": true, "unser": true,
+	"unsere": true, "euer": true, "sich": true, "dies": true,
+	"diese": true, "dieser": true, "dieses": true, "jener": true,
+	"was": true, "wer": true, "welche": true, "welcher": true,
+	"man": true, "etwas": true, "nichts": true, "jemand": true,
+	"niemand": true, "jeder": true, "jede": true,
+	// Common verbs
+	"ist": true, "bin": true, "bist": true, "sind": true, "seid": true,
+	"war": true, "waren": true, "wird": true, "werden": true,
+	"wurde": true, "wurden": true, "gewesen": true, "habe": true,
+	"hast": true, "hat": true, "haben": true, "hatte": true,
+	"hatten": true, "kann": true, "kannst": true, "können": true,
+	"konnte": true, "muss": true, "müssen": true, "musste": true,
+	"soll": true, "sollen": true, "sollte": true, "will": true,
+	"wollen": true, "wollte": true, "darf": true, "dürfen": true,
+	"mag": true, "mache": true, "macht": true, "machen": true,
+	"geht": true, "gehen": true, "ging": true, "gibt": true,
+	"geben": true, "kommt": true, "kommen": true, "lassen": true,
+	// Conjunctions
+	"und": true, "oder": true, "aber": true, "denn": true,
+	"sondern": true, "weil": true, "dass": true, "wenn": true,
+	"als": true, "ob": true, "obwohl": true, "doch": true, "sowie": true,
+	// Common adverbs
+	"nicht": true, "kein": true, "keine": true, "auch": true,
+	"noch": true, "schon": true, "nur": true, "sehr": true, "so": true,
+	"hier": true, "dort": true, "da": true, "jetzt": true, "dann": true,
+	"immer": true, "nie": true, "oft": true, "mal": true, "mehr": true,
+	"viel": true, "viele": true, "wieder": true, "ganz": true,
+	"gar": true, "eben": true, "halt": true, "wohl": true,
+	"einfach": true, "eigentlich": true, "vielleicht": true,
+	// Other common words
+	"alle": true, "alles": true, "andere": true, "anderen": true,
+	"tag": true, "jahr": true, "zeit": true, "neu": true, "neue": true,
+	"neuen": true, "alt": true, "alte": true, "erste": true,
+	"letzte": true, "gut": true, "gute": true, "groß": true,
+	"große": true, "klein": true, "kleine": true,
+}
+
+// frenchStopWords contains common French words to exclude from topic extraction.
+var frenchStopWords = map[string]bool{
+	// Articles
+	"le": true, "la": true, "les": true, "un": true, "une": true,
+	"des": true, "du": true, "de": true, "d": true, "l": true,
+	// Prepositions
+	"à": true, "au": true, "aux": true, "dans": true, "sur": true,
+	"sous": true, "avec": true, "sans": true, "pour": true, "par": true,
+	"entre": true, "vers": true, "chez": true, "avant": true,
+	"après": true, "depuis": true, "pendant": true, "contre": true,
+	"selon": true,
+	// Pronouns
+	"je": true, "tu": true, "il": true, "elle": true, "on": true,
+	"nous": true, "vous": true, "ils": true, "elles": true, "me": true,
+	"te": true, "se": true, "moi": true, "toi": true, "lui": true,
+	"leur": true, "leurs": true, "eux": true, "mon": true, "ma": true,
+	"mes": true, "ton": true, "ta": true, "tes": true, "son": true,
+	"sa": true, "ses": true, "notre": true, "nos": true, "votre": true,
+	"vos": true, "ce": true, "cet": true, "cette": true, "ces": true,
+	"cela": true, "ça": true, "qui": true, "que": true, "quoi": true,
+	"dont": true, "où": true, "quel": true, "quelle": true, "quels": true,
+	"quelles": true, "tout": true, "tous": true, "toute": true,
+	"toutes": true, "rien": true, "personne": true, "chaque": true,
+	// Common verbs
+	"est": true, "suis": true, "es": true, "sommes": true, "êtes": true,
+	"sont": true, "était": true, "étaient": true, "été": true,
+	"être": true, "ai": true, "as": true, "a": true, "avons": true,
+	"avez": true, "ont": true, "avait": true, "avaient": true,
+	"avoir": true, "fait": true, "faire": true, "font": true,
+	"peut": true, "peux": true, "pouvons": true, "pouvez": true,
+	"peuvent": true, "pouvait": true, "doit": true, "dois": true,
+	"doivent": true, "veut": true, "veux": true, "voulons": true,
+	"vont": true, "va": true, "aller": true, "vais": true,
+	// Conjunctions
+	"et": true, "ou": true, "mais": true, "donc": true, "car": true,
+	"ni": true, "or": true, "si": true, "comme": true, "quand": true,
+	"parce": true, "puisque": true, "lorsque": true,
+	// Common adverbs
+	"ne": true, "pas": true, "plus": true, "non": true, "oui": true,
+	"aussi": true, "encore": true, "déjà": true, "très": true,
+	"trop": true, "bien": true, "peu": true, "beaucoup": true,
+	"ici": true, "là": true, "alors": true, "puis": true,
+	"toujours": true, "jamais": true, "souvent": true, "même": true,
+	"seulement": true, "vraiment": true,
+	// Other common words
+	"autre": true, "autres": true, "jour": true, "jours": true,
+	"an": true, "ans": true, "année": true, "temps": true,
+	"nouveau": true, "nouvelle": true, "premier": true, "première": true,
+	"dernier": true, "dernière": true, "bon": true, "bonne": true,
+	"grand": true, "grande": true, "petit": true, "petite": true,
+}
+
+// spanishStopWords contains common Spanish words to exclude from topic extraction.
+var spanishStopWords = map[string]bool{
+	// Articles
+	"el": true, "la": true, "los": true, "las": true, "un": true,
+	"una": true, "unos": true, "unas": true, "lo": true,
+	// Prepositions
+	"a": true, "al": true, "de": true, "del": true, "en": true,
+	"con": true, "sin": true, "por": true, "para": true, "sobre": true,
+	"bajo": true, "entre": true, "hacia": true, "hasta": true,
+	"desde": true, "durante": true, "contra": true, "según": true,
+	"tras": true, "ante": true,
+	// Pronouns
+	"yo": true, "tú": true, "él": true, "ella": true, "usted": true,
+	"nosotros": true, "nosotras": true, "vosotros": true, "ellos": true,
+	"ellas": true, "ustedes": true, "me": true, "te": true, "se": true,
+	"nos": true, "os": true, "le": true, "les": true, "mi": true,
+	"mis": true, "tu": true, "tus": true, "su": true, "sus": true,
+	"nuestro": true, "nuestra": true, "este": true, "esta": true,
+	"estos": true, "estas": true, "ese": true, "esa": true, "esos": true,
+	"esas": true, "eso": true, "esto": true, "que": true, "qué": true,
+	"quien": true, "quién": true, "cual": true, "cuál": true,
+	"donde": true, "dónde": true, "algo": true, "nada": true,
+	"alguien": true, "nadie": true, "cada": true, "todo": true,
+	"toda": true, "todos": true, "todas": true,
+	// Common verbs
+	"es": true, "soy": true, "eres": true, "somos": true, "son": true,
+	"era": true, "eran": true, "fue": true, "fueron": true, "ser": true,
+	"sido": true, "estoy": true, "está": true, "están": true,
+	"estaba": true, "estar": true, "he": true, "has": true, "ha": true,
+	"hemos": true, "han": true, "había": true, "haber": true, "hay": true,
+	"hace": true, "hacer": true, "hizo": true, "puede": true,
+	"pueden": true, "poder": true, "tiene": true, "tienen": true,
+	"tener": true, "va": true, "van": true, "ir": true, "voy": true,
+	// Conjunctions
+	"y": true, "e": true, "o": true, "u": true, "pero": true,
+	"sino": true, "porque": true, "pues": true, "si": true, "como": true,
+	"cuando": true, "aunque": true, "ni": true,
+	// Common adverbs
+	"no": true, "sí": true, "también": true, "tampoco": true, "ya": true,
+	"muy": true, "más": true, "menos": true, "mucho": true, "poco": true,
+	"aquí": true, "allí": true, "ahora": true, "luego": true,
+	"entonces": true, "siempre": true, "nunca": true, "bien": true,
+	"mal": true, "solo": true, "sólo": true, "aún": true, "todavía": true,
+	"así": true,
+	// Other common words
+	"otro": true, "otra": true, "otros": true, "otras": true, "día": true,
+	"días": true, "año": true, "años": true, "vez": true, "tiempo": true,
+	"nuevo": true, "nueva": true, "primer": true, "primero": true,
+	"primera": true, "último": true, "última": true, "bueno": true,
+	"buena": true, "gran": true, "grande": true, "pequeño": true,
+}
@@ -0,0 +1,106 @@
+package topics
+
+import (
+	"cmp"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/jeroendee/ssg/internal/model"
+)
+
+// Extraction modes of [model.TopicSettings].
+const (
+	ModeFrequency = "frequency" // Single words ranked by count (default)
+	ModeTFIDF     = "tfidf"     // Words and phrases ranked by TF-IDF weight
+)
+
+// maxPhraseWords is the number of words in the longest phrase topic.
+const maxPhraseWords = 3
+
+// ExtractWithCorpus extracts topics from plain text, such as the Text of a
+// page or post, with settings. In frequency mode it ranks single words by
+// count and ignores corpus. In TF-IDF mode it also finds recurring phrases of two and three
+// words, such as "exploratory testing", and ranks words and phrases by their
+// count weighted by inverse document frequency against corpus, the text of
+// the site's other pages and posts. Words that only occur within a recurring
+// phrase are left out in favour of the phrase.
+func ExtractWithCorpus(text string, corpus []string, settings model.TopicSettings) []model.Topic {
+	if text == "" {
+		return nil
+	}
+
+	if settings.Mode != ModeTFIDF {
+		return extractFrequency(text, settings)
+	}
+
+	e := newExtractor(settings)
+	minCount := cmp.Or(settings.MinCount, DefaultMinCount)
+
+	candidates := make(map[string]model.Topic)
+	for key, forms := range e.terms(text, maxPhraseWords) {
+		if topic := groupForms(forms); topic.Count >= minCount {
+			candidates[key] = topic
+		}
+	}
+	dropSubsumed(candidates)
+
+	// Count the documents containing each candidate, this one included
+	df := make(map[string]int, len(candidates))
+	for key := range candidates {
+		df[key] = 1
+	}
+	for _, doc := range corpus {
+		terms := e.terms(doc, maxPhraseWords)
+		for key := range candidates {
+			if terms[key] != nil {
+				df[key]++
+			}
+		}
+	}
+
+	type weighted struct {
+		topic  model.Topic
+		weight float64
+	}
+	docs := float64(len(corpus) + 1)
+	result := make([]weighted, 0, len(candidates))
+	for key, topic := range candidates {
+		idf := math.Log((1+docs)/(1+float64(df[key]))) + 1
+		result = append(result, weighted{topic, float64(topic.Count) * idf})
+	}
+
+	// Sort: weight descending, alphabetical tiebreaker
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].weight != result[j].weight {
+			return result[i].weight > result[j].weight
+		}
+		return result[i].topic.Word < result[j].topic.Word
+	})
+
+	limit := cmp.Or(settings.Limit, DefaultLimit)
+	topics := make([]model.Topic, 0, min(limit, len(result)))
+	for _, w := range result[:min(limit, len(result))] {
+		topics = append(topics, w.topic)
+	}
+	return topics
+}
+
+// dropSubsumed removes the candidates that only occur within a longer
+// candidate phrase, such as "exploratory" when every mention is part of
+// "exploratory testing".
+func dropSubsumed(candidates map[string]model.Topic) {
+	for key, topic := range candidates {
+		for other, phrase := range candidates {
+			if len(other) > len(key) && phrase.Count >= topic.Count && containsTerm(other, key) {
+				delete(candidates, key)
+				break
+			}
+		}
+	}
+}
+
+// containsTerm reports whether phrase contains the whole words of term.
+func containsTerm(phrase, term string) bool {
+	return strings.Contains(" "+phrase+" ", " "+term+" ")
+}
@@ -1,49 +1,56 @@
 package topics
 
 import (
+	"cmp"
 	"regexp"
 	"sort"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/jeroendee/ssg/internal/model"
 )
 
-// Extract extracts recurring subject words from markdown content and returns
+// Default extraction settings, used for zero fields of [model.TopicSettings].
+const (
+	DefaultLimit     = 18
+	DefaultMinLength = 3
+	DefaultMinCount  = 2
+)
+
+// Extract extracts recurring subject words from plain text and returns
 // them as frequency-counted topics, sorted by count descending with
 // alphabetical tiebreaker. Returns at most 18 topics. Words must be at
 // least 3 characters long and appear at least 2 times.
-func Extract(markdown string) []model.Topic {
-	if markdown == "" {
-		return nil
-	}
+func Extract(text string) []model.Topic {
+	return ExtractWithSettings(text, model.TopicSettings{})
+}
 
-	// Strip markdown syntax
-	text := stripMarkdown(markdown)
+// ExtractWithSettings extracts topics like [Extract], with the topic cap,
+// minimum word length and minimum frequency taken from settings. Stop words
+// come from the settings' language, English by default, plus its extra stop
+// words. With stemming, inflections such as "test", "tests" and "testing"
+// count as one topic named after their most frequent form. In TF-IDF mode
+// it behaves like [ExtractWithCorpus] with an empty corpus.
+func ExtractWithSettings(text string, settings model.TopicSettings) []model.Topic {
+	return ExtractWithCorpus(text, nil, settings)
+}
 
-	// Tokenize
-	words := tokenize(text)
+// extractFrequency returns the words of text ranked by frequency.
+func extractFrequency(text string, settings model.TopicSettings) []model.Topic {
+	minCount := cmp.Or(settings.MinCount, DefaultMinCount)
 
-	// Count frequencies
-	freq := make(map[string]int)
-	for _, w := range words {
-		w = strings.ToLower(w)
-		if len(w) < 3 {
-			continue
-		}
-		if stopWords[w] {
-			continue
-		}
-		freq[w]++
-	}
+	// Count frequencies of each form, grouped by stem when stemming
+	freq := newExtractor(settings).terms(text, 1)
 
 	// Filter by minimum frequency and build result
 	var result []model.Topic
-	for word, count := range freq {
-		if count < 2 {
+	for _, forms := range freq {
+		topic := groupForms(forms)
+		if topic.Count < minCount {
 			continue
 		}
-		result = append(result, model.Topic{Word: word, Count: count})
+		result = append(result, topic)
 	}
 
 	// Sort: frequency descending, alphabetical tiebreaker
@@ -54,128 +61,134 @@ func Extract(markdown string) []model.Topic {
 		return result[i].Word < result[j].Word
 	})
 
-	// Cap at 18
-	if len(result) > 18 {
-		result = result[:18]
+	// Cap at the topic limit
+	if limit := cmp.Or(settings.Limit, DefaultLimit); len(result) > limit {
+		result = result[:limit]
 	}
 
 	return result
 }
 
-// Regex patterns for markdown stripping.
-var (
-	// Match image references: ![alt](path)
-	reImage = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
-	// Match links: [text](url) — capture text, discard URL
-	reLink = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
-	// Match HTML entities: &amp; &quot; etc.
-	reHTMLEntity = regexp.MustCompile(`&[a-zA-Z]+;`)
-	// Match inline code backticks (keep content)
-	reInlineCode = regexp.MustCompile("`([^`]*)`")
-)
+// extractor filters and groups the words of topic candidates.
+type extractor struct {
+	lang      language
+	stopWords map[string]bool // Extra stop words
+	minLength int
+	stem      bool
+}
 
-// stripMarkdown removes markdown syntax artifacts before tokenization.
-func stripMarkdown(md string) string {
-	// Strip image refs first (before links, since ![...] contains [...])
-	text := reImage.ReplaceAllString(md, "")
-	// Replace links with just the text
-	text = reLink.ReplaceAllString(text, "$1")
-	// Strip HTML entities
-	text = reHTMLEntity.ReplaceAllString(text, "")
-	// Strip inline code backticks but keep content
-	text = reInlineCode.ReplaceAllString(text, "$1")
-	return text
+// newExtractor returns an extractor for settings.
+func newExtractor(settings model.TopicSettings) extractor {
+	e := extractor{
+		lang:      lookupLanguage(settings.Lang),
+		stopWords: make(map[string]bool, len(settings.StopWords)),
+		minLength: cmp.Or(settings.MinLength, DefaultMinLength),
+		stem:      settings.Stem,
+	}
+	for _, w := range settings.StopWords {
+		e.stopWords[strings.ToLower(w)] = true
+	}
+	return e
 }
 
-// tokenize splits text into words, preserving hyphens within words.
-func tokenize(text string) []string {
-	var words []string
-	var current strings.Builder
+// keep reports whether a lowercase word can be part of a topic.
+func (e extractor) keep(word string) bool {
+	return utf8.RuneCountInString(word) >= e.minLength && !e.lang.stopWords[word] && !e.stopWords[word]
+}
 
-	for _, r := range text {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			current.WriteRune(r)
-		} else if r == '-' && current.Len() > 0 {
-			// Peek: hyphen might be part of a compound word
-			current.WriteRune(r)
-		} else {
-			if current.Len() > 0 {
-				w := strings.TrimRight(current.String(), "-")
-				if w != "" {
-					words = append(words, w)
+// key returns the grouping key of a lowercase word: its stem when stemming.
+func (e extractor) key(word string) string {
+	if e.stem {
+		return e.lang.stem(word)
+	}
+	return word
+}
+
+// terms counts the forms of the terms of up to maxWords consecutive kept
+// words in text, grouped by key. Phrases do not span stop words or
+// punctuation that ends a clause.
+func (e extractor) terms(text string, maxWords int) map[string]map[string]int {
+	counts := make(map[string]map[string]int)
+	for _, clause := range reClauseBreak.Split(text, -1) {
+		var words, keys []string
+		for _, w := range tokenize(clause) {
+			w = strings.ToLower(w)
+			if !e.keep(w) {
+				words, keys = nil, nil
+				continue
+			}
+			words = append(words, w)
+			keys = append(keys, e.key(w))
+			for n := 1; n <= maxWords && n <= len(words); n++ {
+				key := strings.Join(keys[len(keys)-n:], " ")
+				if counts[key] == nil {
+					counts[key] = make(map[string]int)
 				}
-				current.Reset()
+				counts[key][strings.Join(words[len(words)-n:], " ")]++
 			}
 		}
 	}
-	if current.Len() > 0 {
-		w := strings.TrimRight(current.String(), "-")
-		if w != "" {
-			words = append(words, w)
+	return counts
+}
+
+// groupForms returns the topic of a group of word forms with their counts,
+// named after the most frequent form with alphabetical tiebreaker. Forms
+// lists all forms of a group with more than one.
+func groupForms(forms map[string]int) model.Topic {
+	var topic model.Topic
+	best := 0
+	for form, count := range forms {
+		topic.Count += count
+		if count > best || count == best && form < topic.Word {
+			topic.Word, best = form, count
+		}
+		if len(forms) > 1 {
+			topic.Forms = append(topic.Forms, form)
 		}
 	}
+	sort.Strings(topic.Forms)
+	return topic
+}
 
+// reClauseBreak matches punctuation and line breaks that end a phrase.
+var reClauseBreak = regexp.MustCompile(`[.,;:!?()\[\]{}"“”|…—\n]+`)
+
+// tokenize splits text into words, preserving hyphens within words.
+func tokenize(text string) []string {
+	spans := wordSpans(text)
+	words := make([]string, len(spans))
+	for i, span := range spans {
+		words[i] = text[span[0]:span[1]]
+	}
 	return words
 }
 
-// stopWords contains common English words to exclude from topic extraction.
-var stopWords = map[string]bool{
-	// Articles
-	"the": true, "a": true, "an": true,
-	// Prepositions
-	"in": true, "on": true, "at": true, "to": true, "for": true,
-	"with": true, "from": true, "by": true, "of": true, "about": true,
-	"into": true, "through": true, "during": true, "before": true,
-	"after": true, "above": true, "below": true, "between": true,
-	"under": true, "over": true, "out": true, "off": true, "up": true,
-	"down": true, "upon": true, "along": true, "across": true, "via": true,
-	// Pronouns
-	"he": true, "she": true, "it": true, "they": true, "we": true,
-	"you": true, "his": true, "her": true, "its": true, "their": true,
-	"our": true, "your": true, "him": true, "them": true, "who": true,
-	"whom": true, "whose": true, "which": true, "that": true,
-	"this": true, "these": true, "those": true, "what": true,
-	"myself": true, "yourself": true, "himself": true, "herself": true,
-	"itself": true, "ourselves": true, "themselves": true,
-	// Common verbs
-	"is": true, "are": true, "was": true, "were": true, "be": true,
-	"been": true, "being": true, "has": true, "have": true, "had": true,
-	"having": true, "do": true, "does": true, "did": true, "doing": true,
-	"will": true, "would": true, "shall": true, "should": true,
-	"may": true, "might": true, "must": true, "can": true, "could": true,
-	"am": true, "get": true, "got": true, "gets": true, "make": true,
-	"made": true, "let": true, "say": true, "said": true, "know": true,
-	"think": true, "take": true, "come": true, "see": true, "want": true,
-	"use": true, "used": true, "using": true, "find": true, "give": true,
-	"tell": true, "work": true, "call": true, "try": true, "ask": true,
-	"need": true, "seem": true, "feel": true, "leave": true, "put": true,
-	"keep": true, "set": true, "run": true, "move": true, "go": true,
-	"went": true, "gone": true, "going": true,
-	// Conjunctions
-	"and": true, "but": true, "or": true, "nor": true, "so": true,
-	"yet": true, "both": true, "either": true, "neither": true,
-	"not": true, "only": true, "own": true, "same": true,
-	// Common adverbs
-	"also": true, "just": true, "then": true, "than": true,
-	"now": true, "here": true, "there": true, "when": true,
-	"where": true, "why": true, "how": true, "all": true,
-	"each": true, "every": true, "any": true, "few": true,
-	"more": true, "most": true, "other": true, "some": true,
-	"such": true, "no": true, "very": true, "too": true,
-	"quite": true, "enough": true, "well": true, "back": true,
-	"still": true, "even": true, "never": true, "always": true,
-	"often": true, "ever": true, "much": true, "many": true,
-	// Other common words
-	"like": true, "one": true, "two": true,
-	"new": true, "old": true, "first": true, "last": true,
-	"long": true, "great": true, "little": true, "right": true,
-	"big": true, "high": true, "small": true, "large": true,
-	"next": true, "early": true, "young": true, "important": true,
-	"public": true, "bad": true, "different": true, "able": true,
-	"way": true, "day": true, "time": true, "year": true,
-	"people": true, "part": true, "place": true, "case": true,
-	"thing": true, "man": true, "world": true, "life": true,
-	"hand": true, "point": true, "end": true, "another": true,
-	"again": true, "don": true, "article": true, "post": true, "dev": true,
-	"based": true,
+// wordSpans returns the byte ranges of the words in text. Words are runs of
+// letters and digits, joined by hyphens within compound words.
+func wordSpans(text string) [][2]int {
+	var spans [][2]int
+	start := -1
+	end := 0 // End of the current word, excluding trailing hyphens
+
+	for i, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if start < 0 {
+				start = i
+			}
+			end = i + utf8.RuneLen(r)
+		case r == '-' && start >= 0:
+			// Peek: hyphen might be part of a compound word
+		default:
+			if start >= 0 {
+				spans = append(spans, [2]int{start, end})
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, end})
+	}
+
+	return spans
 }
@@ -1,12 +1,26 @@
 package topics_test
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/jeroendee/ssg/internal/model"
+	"github.com/jeroendee/ssg/internal/parser"
 	"github.com/jeroendee/ssg/internal/topics"
 )
 
+// plainText returns the plain text of md, as the builder passes it to the
+// extractors.
+func plainText(t *testing.T, md string) string {
+	t.Helper()
+	a, err := parser.Analyze(md)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	return a.Text
+}
+
 func TestExtract_BasicFrequency(t *testing.T) {
 	t.Parallel()
 	md := "Claude is great. Claude helps with coding. Anthropic built Claude."
@@ -196,7 +210,7 @@ func TestExtract_AlphabeticalTiebreaker(t *testing.T) {
 func TestExtract_LinkTextKeptURLDiscarded(t *testing.T) {
 	t.Parallel()
 	md := "[Claude](https://anthropic.com) and [Claude](https://anthropic.com) again"
-	result := topics.Extract(md)
+	result := topics.Extract(plainText(t, md))
 
 	for _, topic := range result {
 		if topic.Word == "https" || topic.Word == "anthropic" || topic.Word == "com" {
@@ -219,7 +233,7 @@ func TestExtract_LinkTextKeptURLDiscarded(t *testing.T) {
 func TestExtract_ImageRefsStripped(t *testing.T) {
 	t.Parallel()
 	md := "![alt text](assets/image.png) docker docker docker"
-	result := topics.Extract(md)
+	result := topics.Extract(plainText(t, md))
 
 	for _, topic := range result {
 		if topic.Word == "assets" || topic.Word == "image" || topic.Word == "png" {
@@ -231,7 +245,7 @@ func TestExtract_ImageRefsStripped(t *testing.T) {
 func TestExtract_HTMLEntitiesStripped(t *testing.T) {
 	t.Parallel()
 	md := "&amp; &quot; docker docker docker"
-	result := topics.Extract(md)
+	result := topics.Extract(plainText(t, md))
 
 	for _, topic := range result {
 		if topic.Word == "amp" || topic.Word == "quot" {
@@ -243,7 +257,7 @@ func TestExtract_HTMLEntitiesStripped(t *testing.T) {
 func TestExtract_InlineCodePreserved(t *testing.T) {
 	t.Parallel()
 	md := "`openspec` and `openspec` and `openspec` and `openspec` and `openspec`"
-	result := topics.Extract(md)
+	result := topics.Extract(plainText(t, md))
 
 	found := false
 	for _, topic := range result {
@@ -273,3 +287,356 @@ func TestExtract_HyphenatedWords(t *testing.T) {
 		t.Errorf("expected 'pre-push' with count 3, got %v", result)
 	}
 }
+
+func TestExtractWithSettings(t *testing.T) {
+	t.Parallel()
+	md := "ai ai ai rust rust rust rust docker docker zig"
+	tests := []struct {
+		name     string
+		settings model.TopicSettings
+		want     []string
+	}{
+		{
+			name: "defaults",
+			want: []string{"rust", "docker"},
+		},
+		{
+			name:     "limit",
+			settings: model.TopicSettings{Limit: 1},
+			want:     []string{"rust"},
+		},
+		{
+			name:     "min length",
+			settings: model.TopicSettings{MinLength: 2},
+			want:     []string{"rust", "ai", "docker"},
+		},
+		{
+			name:     "min count",
+			settings: model.TopicSettings{MinCount: 4},
+			want:     []string{"rust"},
+		},
+		{
+			name:     "min length counts characters",
+			settings: model.TopicSettings{MinLength: 5, MinCount: 1},
+			want:     []string{"docker"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, topic := range topics.ExtractWithSettings(md, tt.settings) {
+				got = append(got, topic.Word)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ExtractWithSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractWithSettings_MinLengthInRunes(t *testing.T) {
+	t.Parallel()
+	result := topics.ExtractWithSettings("éé éé", model.TopicSettings{})
+	if len(result) != 0 {
+		t.Errorf("expected two-letter word to be excluded, got %v", result)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	t.Parallel()
+	text := "Go, golang and GO-routines; go-to go."
+	got := topics.Mentions(text, []string{"go"})
+	var words []string
+	for _, m := range got {
+		words = append(words, text[m[0]:m[1]])
+	}
+	if strings.Join(words, ",") != "Go,go" {
+		t.Errorf("Mentions() = %v, want whole-word matches [Go go]", words)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	t.Parallel()
+	words := strings.Fields("one two three four five six seven eight nine ten eleven twelve thirteen fourteen")
+	long := strings.Join(words, " ") + " Docker " + strings.Join(words, " ")
+
+	tests := []struct {
+		name   string
+		text   string
+		word   string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "short text kept whole",
+			text:   "Moved the  blog to\nDocker today.",
+			word:   "docker",
+			want:   "Moved the blog to Docker today.",
+			wantOK: true,
+		},
+		{
+			name:   "long text cut around mention",
+			text:   long,
+			word:   "docker",
+			want:   "…three four five six seven eight nine ten eleven twelve thirteen fourteen Docker one two three four five six seven eight nine ten eleven twelve…",
+			wantOK: true,
+		},
+		{
+			name: "not mentioned",
+			text: "Dockerfile only.",
+			word: "docker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := topics.Snippet(tt.text, []string{tt.word})
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Snippet() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestExtractWithSettings_Languages(t *testing.T) {
+	t.Parallel()
+	md := "het weer voor een fiets. het weer voor een fiets. the bike and the bike."
+	tests := []struct {
+		name string
+		lang string
+		want []string
+	}{
+		{name: "english by default", want: []string{"bike", "een", "fiets", "het", "voor", "weer"}},
+		{name: "dutch", lang: "nl", want: []string{"bike", "fiets", "the"}},
+		{name: "regional tag", lang: "nl-BE", want: []string{"bike", "fiets", "the"}},
+		{name: "unsupported falls back to english", lang: "xx", want: []string{"bike", "een", "fiets", "het", "voor", "weer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, topic := range topics.ExtractWithSettings(md, model.TopicSettings{Lang: tt.lang}) {
+				got = append(got, topic.Word)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ExtractWithSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractWithSettings_ExtraStopWords(t *testing.T) {
+	t.Parallel()
+	md := "moment moment docker docker"
+	result := topics.ExtractWithSettings(md, model.TopicSettings{StopWords: []string{"Moment"}})
+	if len(result) != 1 || result[0].Word != "docker" {
+		t.Errorf("expected only 'docker', got %v", result)
+	}
+}
+
+func TestExtractWithSettings_Stemming(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		lang      string
+		md        string
+		wantWord  string
+		wantCount int
+		wantForms []string
+	}{
+		{
+			name:      "english inflections",
+			md:        "test tests testing tests tested",
+			wantWord:  "tests",
+			wantCount: 5,
+			wantForms: []string{"test", "tested", "testing", "tests"},
+		},
+		{
+			name:      "english doubled consonant",
+			md:        "running runs running",
+			wantWord:  "running",
+			wantCount: 3,
+			wantForms: []string{"running", "runs"},
+		},
+		{
+			name:      "english plural ies",
+			md:        "library libraries libraries",
+			wantWord:  "libraries",
+			wantCount: 3,
+			wantForms: []string{"libraries", "library"},
+		},
+		{
+			name:      "dutch plural",
+			lang:      "nl",
+			md:        "boek boeken boeken",
+			wantWord:  "boeken",
+			wantCount: 3,
+			wantForms: []string{"boek", "boeken"},
+		},
+		{
+			name:      "tie picks alphabetical form",
+			md:        "process processes",
+			wantWord:  "process",
+			wantCount: 2,
+			wantForms: []string{"process", "processes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := topics.ExtractWithSettings(tt.md, model.TopicSettings{Lang: tt.lang, Stem: true})
+			if len(result) != 1 {
+				t.Fatalf("expected one topic, got %v", result)
+			}
+			got := result[0]
+			if got.Word != tt.wantWord || got.Count != tt.wantCount || strings.Join(got.Forms, ",") != strings.Join(tt.wantForms, ",") {
+				t.Errorf("topic = %+v, want %s (%d) with forms %v", got, tt.wantWord, tt.wantCount, tt.wantForms)
+			}
+		})
+	}
+}
+
+func TestExtract_NoStemmingByDefault(t *testing.T) {
+	t.Parallel()
+	result := topics.Extract("test test tests tests")
+	if len(result) != 2 {
+		t.Errorf("expected 'test' and 'tests' as separate topics, got %v", result)
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{"nl", "nl", true},
+		{"NL-be", "nl", true},
+		{"de_AT", "de", true},
+		{"xx", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := topics.Language(tt.tag)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Language(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if got := strings.Join(topics.Languages(), ","); got != "de,en,es,fr,nl" {
+		t.Errorf("Languages() = %s", got)
+	}
+}
+
+func TestReadStopWords(t *testing.T) {
+	t.Parallel()
+	words, err := topics.ReadStopWords(strings.NewReader("# Site words\nMoment moments\n\nvandaag # today\n"))
+	if err != nil {
+		t.Fatalf("ReadStopWords() error = %v", err)
+	}
+	if got := strings.Join(words, ","); got != "moment,moments,vandaag" {
+		t.Errorf("ReadStopWords() = %v", words)
+	}
+}
+
+func TestExtractWithCorpus_TFIDF(t *testing.T) {
+	t.Parallel()
+	md := "Exploratory testing on Monday. More exploratory testing today.\n" +
+		"Exploratory testing with charters. Release today, release notes. Quiet today."
+	corpus := []string{
+		"Today was quiet.",
+		"Nothing today.",
+		"A release today.",
+	}
+
+	result := topics.ExtractWithCorpus(md, corpus, model.TopicSettings{Mode: topics.ModeTFIDF})
+	var got []string
+	for _, topic := range result {
+		got = append(got, fmt.Sprintf("%s:%d", topic.Word, topic.Count))
+	}
+	// "exploratory" and "testing" only occur in the phrase, and "today" is
+	// common across the site, so it ranks below the rarer "release".
+	want := "exploratory testing:3,release:2,today:3"
+	if strings.Join(got, ",") != want {
+		t.Errorf("ExtractWithCorpus() = %v, want %s", got, want)
+	}
+}
+
+func TestExtractWithCorpus_FrequencyIgnoresCorpus(t *testing.T) {
+	t.Parallel()
+	md := "exploratory testing. exploratory testing."
+	result := topics.ExtractWithCorpus(md, []string{"exploratory"}, model.TopicSettings{})
+	var got []string
+	for _, topic := range result {
+		got = append(got, topic.Word)
+	}
+	if strings.Join(got, ",") != "exploratory,testing" {
+		t.Errorf("ExtractWithCorpus() = %v, want single words by frequency", got)
+	}
+}
+
+func TestExtractWithCorpus_PhraseBoundaries(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{
+			name: "three-word phrase",
+			md:   "test driven development. test driven development.",
+			want: "test driven development",
+		},
+		{
+			name: "punctuation breaks phrases",
+			md:   "docker, compose. docker, compose.",
+			want: "compose,docker",
+		},
+		{
+			name: "stop words break phrases",
+			md:   "docker and compose. docker and compose.",
+			want: "compose,docker",
+		},
+		{
+			name: "stemmed phrases group",
+			md:   "unit test. unit tests. unit tests.",
+			want: "unit tests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, topic := range topics.ExtractWithSettings(tt.md, model.TopicSettings{Mode: topics.ModeTFIDF, Stem: true}) {
+				got = append(got, topic.Word)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("ExtractWithSettings() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentions_Phrases(t *testing.T) {
+	t.Parallel()
+	text := "Exploratory  testing beats exploratory, testing and exploratory tests."
+	got := topics.Mentions(text, []string{"exploratory testing", "exploratory tests"})
+	var words []string
+	for _, m := range got {
+		words = append(words, text[m[0]:m[1]])
+	}
+	if strings.Join(words, "|") != "Exploratory  testing|exploratory tests" {
+		t.Errorf("Mentions() = %q", words)
+	}
+
+	snippet, ok := topics.Snippet(text, []string{"exploratory tests"})
+	if !ok || snippet != "Exploratory testing beats exploratory, testing and exploratory tests." {
+		t.Errorf("Snippet() = %q, %v", snippet, ok)
+	}
+}
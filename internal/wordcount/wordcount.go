@@ -1,48 +1,34 @@
 package wordcount
 
 import (
-	"bytes"
-	"regexp"
-	"strings"
-
-	"github.com/yuin/goldmark"
+	"github.com/jeroendee/ssg/internal/parser"
+	"github.com/jeroendee/ssg/internal/textcount"
 )
 
+// DefaultWordsPerMinute is the reading speed used when none is configured.
+const DefaultWordsPerMinute = textcount.DefaultWordsPerMinute
+
+// Counts holds the words of a text by script.
+type Counts = textcount.Counts
+
 // Count returns the number of words in the given markdown text.
-// It excludes code blocks from the word count.
+// It excludes code blocks from the word count. Chinese and Japanese
+// characters count as a word each.
 func Count(text string) int {
-	if text == "" || strings.TrimSpace(text) == "" {
-		return 0
-	}
-
-	// Convert markdown to HTML using goldmark
-	var buf bytes.Buffer
-	md := goldmark.New()
-	if err := md.Convert([]byte(text), &buf); err != nil {
+	a, err := parser.Analyze(text)
+	if err != nil {
 		return 0
 	}
-
-	html := buf.String()
-
-	// Remove <pre>...</pre> and <code>...</code> blocks entirely
-	html = removeCodeBlocks(html)
-
-	// Strip remaining HTML tags
-	html = stripHTMLTags(html)
-
-	// Count words using strings.Fields on plain text
-	return len(strings.Fields(html))
+	return a.Words.Total()
 }
 
-// removeCodeBlocks removes <pre>...</pre> blocks entirely (fenced code blocks).
-// Inline <code> tags are preserved (will be stripped later with other HTML tags).
-func removeCodeBlocks(html string) string {
-	preRegex := regexp.MustCompile(`(?s)<pre>.*?</pre>`)
-	return preRegex.ReplaceAllString(html, "")
+// CountText returns the word counts of plain text, as [textcount.Count].
+func CountText(text string) Counts {
+	return textcount.Count(text)
 }
 
-// stripHTMLTags removes all HTML tags from the input.
-func stripHTMLTags(html string) string {
-	tagRegex := regexp.MustCompile(`<[^>]*>`)
-	return tagRegex.ReplaceAllString(html, " ")
+// ReadingTime returns the estimated reading time in whole minutes of text
+// with counts c, as [textcount.ReadingTime].
+func ReadingTime(c Counts, wordsPerMinute int) int {
+	return textcount.ReadingTime(c, wordsPerMinute)
 }
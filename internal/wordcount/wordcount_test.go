@@ -82,6 +82,41 @@ func TestCount(t *testing.T) {
 			text: "```\ncode here\n```",
 			want: 0,
 		},
+		{
+			name: "chinese characters counted individually",
+			text: "我喜欢写代码。",
+			want: 6,
+		},
+		{
+			name: "japanese kana and kanji counted individually",
+			text: "ひらがなとカタカナ",
+			want: 9,
+		},
+		{
+			name: "mixed script",
+			text: "我用Go写代码 every day",
+			want: 8,
+		},
+		{
+			name: "korean counted as words",
+			text: "안녕하세요 세계",
+			want: 2,
+		},
+		{
+			name: "table cells counted without syntax",
+			text: "| Name | Role |\n| --- | --- |\n| Jane Doe | Tester |",
+			want: 5,
+		},
+		{
+			name: "image alt text excluded",
+			text: "![a long description](photo.jpg) Caption here",
+			want: 2,
+		},
+		{
+			name: "stray punctuation excluded",
+			text: "Tom & Jerry - friends",
+			want: 3,
+		},
 	}
 
 	for _, tt := range tests {
@@ -96,3 +131,39 @@ func TestCount(t *testing.T) {
 		})
 	}
 }
+
+func TestReadingTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		counts         wordcount.Counts
+		wordsPerMinute int
+		want           int
+	}{
+		{name: "empty", counts: wordcount.Counts{}, want: 0},
+		{name: "short text takes a minute", counts: wordcount.Counts{Words: 20}, want: 1},
+		{name: "default speed", counts: wordcount.Counts{Words: 1000}, want: 5},
+		{name: "configured speed", counts: wordcount.Counts{Words: 1000}, wordsPerMinute: 250, want: 4},
+		{name: "cjk characters read faster", counts: wordcount.Counts{CJK: 1500}, want: 3},
+		{name: "mixed script", counts: wordcount.Counts{Words: 400, CJK: 500}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := wordcount.ReadingTime(tt.counts, tt.wordsPerMinute); got != tt.want {
+				t.Errorf("ReadingTime() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountText(t *testing.T) {
+	t.Parallel()
+	got := wordcount.CountText("Testing 测试 in テスト, twice.")
+	want := wordcount.Counts{Words: 3, CJK: 5}
+	if got != want {
+		t.Errorf("CountText() = %+v, want %+v", got, want)
+	}
+}